@@ -0,0 +1,129 @@
+// Benchmark 16: Pluggable allocator interface vs concrete bump allocator
+// Run: go run alloc_strategy.go
+//
+// High-performance systems often make the allocation path pluggable:
+//   type Allocator interface { New() *Point }
+// That puts an interface call on every allocation.
+// A bump allocator is only an index increment into a preallocated []Point,
+// so the dispatch can be a large share of the total cost.
+// Calling the concrete allocator directly lets New() inline into the loop.
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Interface (dynamic dispatch per allocation)
+type Allocator interface {
+	New() *Point
+	Reset()
+}
+
+// Bump allocator: hands out consecutive slots of one contiguous []Point
+type BumpAllocator struct {
+	buf  []Point
+	next int
+}
+
+func NewBumpAllocator(capacity int) *BumpAllocator {
+	return &BumpAllocator{buf: make([]Point, capacity)}
+}
+
+func (a *BumpAllocator) New() *Point {
+	p := &a.buf[a.next]
+	a.next++
+	return p
+}
+
+func (a *BumpAllocator) Reset() {
+	clear(a.buf[:a.next])
+	a.next = 0
+}
+
+// Global to prevent optimizer from eliminating allocations
+var gSum int
+
+// Benchmark allocation through the Allocator interface
+func benchmarkInterface(alloc Allocator, n int, rounds int) time.Duration {
+	start := time.Now()
+
+	for r := 0; r < rounds; r++ {
+		sum := 0
+		for i := 0; i < n; i++ {
+			p := alloc.New()  // Interface call
+			p.X = i
+			p.Y = r
+			sum += p.X
+		}
+		gSum = sum
+		alloc.Reset()
+	}
+
+	return time.Since(start)
+}
+
+// Benchmark allocation through the concrete bump allocator
+func benchmarkConcrete(alloc *BumpAllocator, n int, rounds int) time.Duration {
+	start := time.Now()
+
+	for r := 0; r < rounds; r++ {
+		sum := 0
+		for i := 0; i < n; i++ {
+			p := alloc.New()  // Direct call (inlinable)
+			p.X = i
+			p.Y = r
+			sum += p.X
+		}
+		gSum = sum
+		alloc.Reset()
+	}
+
+	return time.Since(start)
+}
+
+func main() {
+	const n = 1000000  // Allocations per round
+	const rounds = 100
+
+	fmt.Println("Benchmarking Go allocation path: Allocator interface vs concrete bump allocator")
+	fmt.Printf("Allocations per round: %d\n", n)
+	fmt.Printf("Rounds: %d\n\n", rounds)
+
+	concrete := NewBumpAllocator(n)
+
+	var dispatched Allocator = NewBumpAllocator(n)
+
+	// Warm up
+	benchmarkInterface(dispatched, 1000, 10)
+	benchmarkConcrete(concrete, 1000, 10)
+
+	// Benchmark interface allocator
+	interfaceTime := benchmarkInterface(dispatched, n, rounds)
+	interfacePerAlloc := float64(interfaceTime.Nanoseconds()) / float64(n*rounds)
+
+	fmt.Println("Allocator interface (dispatched New()):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(interfaceTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per allocation: %.2f ns\n\n", interfacePerAlloc)
+
+	// Benchmark concrete allocator
+	concreteTime := benchmarkConcrete(concrete, n, rounds)
+	concretePerAlloc := float64(concreteTime.Nanoseconds()) / float64(n*rounds)
+
+	fmt.Println("Concrete *BumpAllocator (direct New()):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(concreteTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per allocation: %.2f ns\n\n", concretePerAlloc)
+
+	// Calculate overhead
+	speedup := float64(interfaceTime) / float64(concreteTime)
+	fmt.Printf("Dispatch overhead: %.2f ns per allocation\n", interfacePerAlloc-concretePerAlloc)
+	fmt.Printf("Speedup: %.2fx faster for concrete allocator\n", speedup)
+	fmt.Println("\nConclusion: A bump allocation is a few instructions.")
+	fmt.Println("Putting it behind an interface adds a call that can't be inlined,")
+	fmt.Println("a large fraction of what the allocation itself costs.")
+}
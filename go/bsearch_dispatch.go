@@ -0,0 +1,194 @@
+// Benchmark 6: Binary search with interface-dispatched vs generic comparison
+// Run: go run bsearch_dispatch.go
+//
+// A binary search calls its comparison ~log2(n) times per lookup.
+// Three ways to run it:
+//   - sort.Search + Comparator interface: a closure call plus an itab call per probe
+//   - slices.BinarySearchFunc: generic, but compiled per GC shape, so the
+//     comparator is still called indirectly (through a func value) per probe
+//   - hand-written search over []Point: comparePoints is inlined, no call per probe
+// Only the last one removes dispatch from the probe loop.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Interface (dynamic dispatch per comparison)
+type Comparator interface {
+	Compare(a, b Point) int
+}
+
+type byXY struct{}
+
+func (byXY) Compare(a, b Point) int {
+	return comparePoints(a, b)
+}
+
+// Direct comparison (static dispatch, inlinable)
+func comparePoints(a, b Point) int {
+	if a.X != b.X {
+		if a.X < b.X {
+			return -1
+		}
+		return 1
+	}
+	if a.Y != b.Y {
+		if a.Y < b.Y {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// Global to prevent optimizer from eliminating searches
+var gFound int
+
+func makeSorted(n int) []Point {
+	points := make([]Point, n)
+	for i := 0; i < n; i++ {
+		points[i] = Point{X: i / 4, Y: i % 4}
+	}
+	return points
+}
+
+func makeTargets(points []Point, searches int) []Point {
+	r := rand.New(rand.NewSource(1))
+	targets := make([]Point, searches)
+	for i := range targets {
+		targets[i] = points[r.Intn(len(points))]
+	}
+	return targets
+}
+
+// Benchmark sort.Search with an interface comparator
+func benchmarkInterfaceSearch(points, targets []Point, cmp Comparator) time.Duration {
+	start := time.Now()
+
+	found := 0
+	for _, t := range targets {
+		i := sort.Search(len(points), func(i int) bool {
+			return cmp.Compare(points[i], t) >= 0  // Interface call per probe
+		})
+		if i < len(points) && points[i] == t {
+			found++
+		}
+	}
+	gFound = found
+
+	return time.Since(start)
+}
+
+// Benchmark slices.BinarySearchFunc with a plain comparator function
+func benchmarkGenericSearch(points, targets []Point) time.Duration {
+	start := time.Now()
+
+	found := 0
+	for _, t := range targets {
+		if _, ok := slices.BinarySearchFunc(points, t, comparePoints); ok {  // Indirect call per probe
+			found++
+		}
+	}
+	gFound = found
+
+	return time.Since(start)
+}
+
+// Lower-bound binary search specialized for []Point (comparePoints inlines)
+func searchPoints(points []Point, t Point) (int, bool) {
+	lo, hi := 0, len(points)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if comparePoints(points[mid], t) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(points) && points[lo] == t
+}
+
+// Benchmark the hand-written search
+func benchmarkDirectSearch(points, targets []Point) time.Duration {
+	start := time.Now()
+
+	found := 0
+	for _, t := range targets {
+		if _, ok := searchPoints(points, t); ok {  // Inlined comparison per probe
+			found++
+		}
+	}
+	gFound = found
+
+	return time.Since(start)
+}
+
+func runSize(n int, searches int) {
+	points := makeSorted(n)
+	targets := makeTargets(points, searches)
+
+	var cmp Comparator = byXY{}
+
+	// Warm up
+	benchmarkInterfaceSearch(points, targets[:1000], cmp)
+	benchmarkGenericSearch(points, targets[:1000])
+	benchmarkDirectSearch(points, targets[:1000])
+
+	fmt.Printf("Elements: %d (%.1f MB)\n", n, float64(n*16)/(1<<20))
+
+	// Benchmark interface comparison
+	interfaceTime := benchmarkInterfaceSearch(points, targets, cmp)
+	interfacePerSearch := float64(interfaceTime.Nanoseconds()) / float64(searches)
+
+	fmt.Println("  sort.Search + Comparator interface:")
+	fmt.Printf("    Total time: %.2f ms\n", float64(interfaceTime.Microseconds())/1000.0)
+	fmt.Printf("    Time per search: %.1f ns\n", interfacePerSearch)
+
+	// Benchmark generic comparison
+	genericTime := benchmarkGenericSearch(points, targets)
+	genericPerSearch := float64(genericTime.Nanoseconds()) / float64(searches)
+
+	fmt.Println("  slices.BinarySearchFunc + comparator func (indirect per probe):")
+	fmt.Printf("    Total time: %.2f ms\n", float64(genericTime.Microseconds())/1000.0)
+	fmt.Printf("    Time per search: %.1f ns\n", genericPerSearch)
+
+	// Benchmark hand-written search
+	directTime := benchmarkDirectSearch(points, targets)
+	directPerSearch := float64(directTime.Nanoseconds()) / float64(searches)
+
+	fmt.Println("  Hand-written search over []Point (comparison inlined):")
+	fmt.Printf("    Total time: %.2f ms\n", float64(directTime.Microseconds())/1000.0)
+	fmt.Printf("    Time per search: %.1f ns\n", directPerSearch)
+
+	// Calculate overhead relative to the inlined search
+	fmt.Printf("  Dispatch overhead (interface): %.1f ns per search (%.2fx)\n",
+		interfacePerSearch-directPerSearch, float64(interfaceTime)/float64(directTime))
+	fmt.Printf("  Dispatch overhead (BinarySearchFunc): %.1f ns per search (%.2fx)\n\n",
+		genericPerSearch-directPerSearch, float64(genericTime)/float64(directTime))
+}
+
+func main() {
+	const searches = 1000000
+
+	fmt.Println("Benchmarking Go binary search: interface vs generic comparison")
+	fmt.Printf("Searches per size: %d\n\n", searches)
+
+	runSize(4096, searches)     // Fits in L1/L2: comparison cost dominates
+	runSize(1000000, searches)  // Exceeds cache: probe misses dominate
+
+	fmt.Println("Conclusion: Interface dispatch is paid on every probe (~log2(n) per search).")
+	fmt.Println("slices.BinarySearchFunc removes the closure and itab, but its comparator")
+	fmt.Println("is still an indirect call per probe; only the specialized search inlines it.")
+	fmt.Println("Branch mispredictions and (out of cache) probe misses cost all three the same,")
+	fmt.Println("so the gap is a fraction, not a multiple.")
+}
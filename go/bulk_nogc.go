@@ -0,0 +1,145 @@
+// Benchmark 20: Bulk processing with GC enabled vs disabled
+// Run: go run bulk_nogc.go
+//
+// A common tuning trick for batch jobs is debug.SetGCPercent(-1) around the batch:
+// no collections while it runs, at the cost of letting the heap grow.
+// Whether that helps depends on layout:
+//   - []Point in, []Point out: one allocation per batch, nothing for the GC to do
+//   - []*Point in, a new *Point per result: a million objects to mark,
+//     a million more allocated, many collections per batch
+// Value semantics make the GC knob mostly irrelevant.
+// Peak heap is HeapAlloc at the end with GC off (nothing freed) and the GC
+// target (NextGC) with GC on, which bounds the heap between collections.
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Global to prevent optimizer from eliminating work
+var gSum int
+
+type result struct {
+	elapsed time.Duration
+	gcs     uint32
+	peak    uint64
+}
+
+func measure(batches int, gcOff bool, batch func() int) result {
+	var r result
+	var before, m runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	if gcOff {
+		old := debug.SetGCPercent(-1)
+		defer debug.SetGCPercent(old)  // Restore the caller's setting (GOGC may not be 100)
+	}
+
+	start := time.Now()
+	for b := 0; b < batches; b++ {
+		gSum += batch()
+	}
+	r.elapsed = time.Since(start)
+
+	runtime.ReadMemStats(&m)
+	r.gcs = m.NumGC - before.NumGC
+	if gcOff {
+		r.peak = m.HeapAlloc  // Nothing was freed, so this is the peak
+	} else {
+		r.peak = m.NextGC  // With GC on, the heap is held near the GC target
+	}
+	return r
+}
+
+// Value pipeline: []Point in, []Point out
+func valueBatch(points []Point) func() int {
+	return func() int {
+		out := make([]Point, len(points))  // One allocation
+		for i, p := range points {
+			out[i] = Point{X: p.X*2 + 1, Y: p.Y - 3}
+		}
+		sum := 0
+		for i := range out {
+			sum += out[i].X
+		}
+		return sum
+	}
+}
+
+// Pointer pipeline: []*Point in, a new *Point per result
+func pointerBatch(points []*Point) func() int {
+	return func() int {
+		out := make([]*Point, len(points))
+		for i, p := range points {
+			out[i] = &Point{X: p.X*2 + 1, Y: p.Y - 3}  // One allocation per element
+		}
+		sum := 0
+		for _, p := range out {
+			sum += p.X
+		}
+		return sum
+	}
+}
+
+func report(name string, n, batches int, r result) {
+	fmt.Println(name)
+	fmt.Printf("  Total time: %.2f ms\n", float64(r.elapsed.Microseconds())/1000.0)
+	fmt.Printf("  Throughput: %.1f M elements/s\n", float64(n*batches)/r.elapsed.Seconds()/1e6)
+	fmt.Printf("  GC cycles: %d\n", r.gcs)
+	fmt.Printf("  Peak heap: %.1f MB\n\n", float64(r.peak)/(1<<20))
+}
+
+func main() {
+	const n = 1000000  // 1 million points
+	const batches = 10
+
+	fmt.Println("Benchmarking Go bulk processing: GC on vs GC off (SetGCPercent(-1))")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Batches: %d\n\n", batches)
+
+	values := make([]Point, n)
+	for i := range values {
+		values[i] = Point{X: i, Y: i}
+	}
+
+	// Warm up
+	measure(1, false, valueBatch(values[:1000]))
+
+	valueOn := measure(batches, false, valueBatch(values))
+	valueOff := measure(batches, true, valueBatch(values))
+	report("Value slice ([]Point), GC on:", n, batches, valueOn)
+	report("Value slice ([]Point), GC off:", n, batches, valueOff)
+	values = nil
+
+	pointers := make([]*Point, n)
+	for i := range pointers {
+		pointers[i] = &Point{X: i, Y: i}
+	}
+
+	pointerOn := measure(batches, false, pointerBatch(pointers))
+	pointerOff := measure(batches, true, pointerBatch(pointers))
+	report("Pointer slice ([]*Point), GC on:", n, batches, pointerOn)
+	report("Pointer slice ([]*Point), GC off:", n, batches, pointerOff)
+
+	fmt.Printf("GC-off speedup (values): %.2fx\n", float64(valueOn.elapsed)/float64(valueOff.elapsed))
+	fmt.Printf("GC-off speedup (pointers): %.2fx\n", float64(pointerOn.elapsed)/float64(pointerOff.elapsed))
+	fmt.Printf("Values vs pointers (GC on): %.2fx faster for values\n",
+		float64(pointerOn.elapsed)/float64(valueOn.elapsed))
+	fmt.Println("\nNote: With GC off, every batch's garbage stays on the heap until GC")
+	fmt.Println("is re-enabled. Peak heap shows the price; the deferred collection is untimed.")
+	fmt.Println("\nConclusion: Value slices create almost no GC work, so turning GC off")
+	fmt.Println("buys little, and can even lose: each batch touches fresh pages")
+	fmt.Println("instead of reusing collected memory. Pointer-heavy batches gain more,")
+	fmt.Println("but pay in peak memory.")
+	fmt.Println("Fixing the layout beats tuning the collector.")
+}
@@ -0,0 +1,143 @@
+// Benchmark 17: Multi-field comparison chains vs precomputed packed keys
+// Run: go run comparison_key.go
+//
+// Sorting and deduplicating []Point by (X, Y) normally compares field by field:
+//   if a.X != b.X { ... } else if a.Y != b.Y { ... }
+// That is a comparison function call and up to two branches per comparison,
+// and sort does O(n log n) comparisons.
+// If the fields fit, pack them once into a single int64 key per point:
+//   key = X<<32 | Y (with Y's sign bit flipped so the order is preserved)
+// Then sort and dedup plain int64s: one compare, no callback, and
+// slices.Sort uses its specialized ordered path.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+type Point struct {
+	X, Y int32
+}
+
+// Field-by-field comparison (the usual cmp function)
+func comparePoints(a, b Point) int {
+	if a.X != b.X {
+		if a.X < b.X {
+			return -1
+		}
+		return 1
+	}
+	if a.Y != b.Y {
+		if a.Y < b.Y {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// Pack (X, Y) into one int64 whose order matches comparePoints
+func packKey(p Point) int64 {
+	return int64(p.X)<<32 | int64(uint32(p.Y)^0x80000000)
+}
+
+func unpackKey(k int64) Point {
+	return Point{X: int32(k >> 32), Y: int32(uint32(k) ^ 0x80000000)}
+}
+
+func makePoints(n int) []Point {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, n)
+	for i := range points {
+		// Small range so duplicates exist and many X values tie
+		points[i] = Point{X: int32(r.Intn(2000) - 1000), Y: int32(r.Intn(2000) - 1000)}
+	}
+	return points
+}
+
+// Sort and dedup comparing fields every time
+func benchmarkFields(src []Point, rounds int) (time.Duration, []Point) {
+	var out []Point
+	work := make([]Point, len(src))
+
+	start := time.Now()
+
+	for r := 0; r < rounds; r++ {
+		copy(work, src)
+		slices.SortFunc(work, comparePoints)
+		out = slices.CompactFunc(work, func(a, b Point) bool { return a == b })
+	}
+
+	return time.Since(start), out
+}
+
+// Precompute packed keys, sort and dedup the keys, unpack the result
+func benchmarkPacked(src []Point, rounds int) (time.Duration, []Point) {
+	var out []Point
+	keys := make([]int64, len(src))
+	work := make([]Point, len(src))
+
+	start := time.Now()
+
+	for r := 0; r < rounds; r++ {
+		for i, p := range src {
+			keys[i] = packKey(p)  // One key per point, computed once
+		}
+		slices.Sort(keys)
+		uniq := slices.Compact(keys)
+		out = work[:len(uniq)]
+		for i, k := range uniq {
+			out[i] = unpackKey(k)
+		}
+	}
+
+	return time.Since(start), out
+}
+
+func main() {
+	const n = 1000000  // 1 million points
+	const rounds = 10
+
+	src := makePoints(n)
+
+	fmt.Println("Benchmarking Go sort + dedup: field comparison vs packed int64 key")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Rounds: %d\n\n", rounds)
+
+	// Warm up
+	benchmarkFields(src[:1000], 10)
+	benchmarkPacked(src[:1000], 10)
+
+	// Benchmark field-by-field comparison
+	fieldsTime, fieldsOut := benchmarkFields(src, rounds)
+	fieldsPerOp := float64(fieldsTime.Microseconds()) / 1000.0 / float64(rounds)
+
+	fmt.Println("Field comparison (slices.SortFunc + CompactFunc):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(fieldsTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per sort+dedup: %.2f ms\n\n", fieldsPerOp)
+
+	// Benchmark packed keys
+	packedTime, packedOut := benchmarkPacked(src, rounds)
+	packedPerOp := float64(packedTime.Microseconds()) / 1000.0 / float64(rounds)
+
+	fmt.Println("Packed int64 key (pack + slices.Sort + Compact + unpack):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(packedTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per sort+dedup: %.2f ms\n\n", packedPerOp)
+
+	// Both must produce the same sorted, unique points
+	if !slices.Equal(fieldsOut, packedOut) {
+		panic("results differ")
+	}
+
+	// Calculate speedup
+	speedup := float64(fieldsTime) / float64(packedTime)
+	fmt.Printf("Unique points: %d\n", len(packedOut))
+	fmt.Printf("Speedup: %.2fx faster for packed keys\n", speedup)
+	fmt.Println("\nConclusion: Packing fields into one key moves the work out of the")
+	fmt.Println("O(n log n) comparison loop into a single O(n) pass.")
+	fmt.Println("It only works when the fields fit, and the packing must preserve order.")
+}
@@ -0,0 +1,142 @@
+// Benchmark 28: Construction pattern, factory + append vs bulk in-place fill
+// Run: go run construct_bulk.go
+//
+// Benchmark 3 (allocation.go) measures where objects live.
+// (allocation.go constructs inline; createStack here is a local factory
+// in the same style, since each benchmark is a standalone program.)
+// This one holds storage fixed (one preallocated []Point) and isolates
+// how the elements are constructed:
+//   - factory + append: p := createStack(i); s = append(s, p)
+//     a call returning a struct by value, then a copy plus len/cap bookkeeping
+//   - bulk fill: s[i] = Point{X: i, Y: i}, written directly into the slice
+// Small factories are usually inlined, so both variants of the factory are shown:
+// inlinable, and forced out of line (as with larger or cross-package factories).
+
+package main
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+type Point struct {
+	X, Y int
+	Data [10]int  // Same layout as allocation.go
+}
+
+// Factory returning a value (the value-storage construction in allocation.go)
+func createStack(i int) Point {
+	p := Point{}
+	p.X = i
+	p.Y = i
+	return p
+}
+
+// Same factory, kept out of line
+//
+//go:noinline
+func createStackNoInline(i int) Point {
+	p := Point{}
+	p.X = i
+	p.Y = i
+	return p
+}
+
+// Global to prevent optimizer from eliminating construction
+var gSum int64
+
+func consume(points []Point) {
+	sum := int64(0)
+	for i := range points {
+		sum += int64(points[i].X + points[i].Y)
+	}
+	gSum = sum
+}
+
+// Factory call + append per element
+func benchmarkFactory(points []Point, rounds int) time.Duration {
+	n := cap(points)
+
+	start := time.Now()
+	for r := 0; r < rounds; r++ {
+		s := points[:0]
+		for i := 0; i < n; i++ {
+			s = append(s, createStack(i))
+		}
+		consume(s)
+	}
+	return time.Since(start)
+}
+
+// Out-of-line factory call + append per element
+func benchmarkFactoryNoInline(points []Point, rounds int) time.Duration {
+	n := cap(points)
+
+	start := time.Now()
+	for r := 0; r < rounds; r++ {
+		s := points[:0]
+		for i := 0; i < n; i++ {
+			s = append(s, createStackNoInline(i))
+		}
+		consume(s)
+	}
+	return time.Since(start)
+}
+
+// Bulk fill: write each element in place
+func benchmarkBulk(points []Point, rounds int) time.Duration {
+	start := time.Now()
+	for r := 0; r < rounds; r++ {
+		s := points[:cap(points)]
+		for i := range s {
+			s[i] = Point{X: i, Y: i}
+		}
+		consume(s)
+	}
+	return time.Since(start)
+}
+
+func main() {
+	const n = 1000000  // 1 million elements
+	const rounds = 20
+
+	points := make([]Point, 0, n)  // Storage shared by all variants
+
+	fmt.Println("Benchmarking Go construction: factory + append vs bulk fill")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Rounds: %d\n", rounds)
+	fmt.Printf("Object size: %d bytes\n\n", int(unsafe.Sizeof(Point{})))
+
+	// Warm up
+	benchmarkFactory(points, 2)
+	benchmarkFactoryNoInline(points, 2)
+	benchmarkBulk(points, 2)
+
+	factoryTime := benchmarkFactory(points, rounds)
+	noInlineTime := benchmarkFactoryNoInline(points, rounds)
+	bulkTime := benchmarkBulk(points, rounds)
+
+	perElement := func(d time.Duration) float64 {
+		return float64(d.Nanoseconds()) / float64(n*rounds)
+	}
+
+	fmt.Println("Factory + append (createStack inlinable):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(factoryTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per element: %.2f ns\n\n", perElement(factoryTime))
+
+	fmt.Println("Factory + append (createStack not inlined):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(noInlineTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per element: %.2f ns\n\n", perElement(noInlineTime))
+
+	fmt.Println("Bulk fill (s[i] written in place):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(bulkTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per element: %.2f ns\n\n", perElement(bulkTime))
+
+	fmt.Printf("Factory overhead (inlined): %.2f ns per element\n", perElement(factoryTime)-perElement(bulkTime))
+	fmt.Printf("Factory overhead (not inlined): %.2f ns per element\n", perElement(noInlineTime)-perElement(bulkTime))
+	fmt.Println("\nNote: Timings include one read pass over the slice per round.")
+	fmt.Println("\nConclusion: With storage fixed, writing s[i] in place is the cheapest way")
+	fmt.Println("to build a []Point. append adds length bookkeeping and a copy per element")
+	fmt.Println("even when the factory is inlined; an out-of-line factory adds a call on top.")
+}
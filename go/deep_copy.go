@@ -0,0 +1,195 @@
+// Benchmark 5: Reflection deep copy vs hand-written clone
+// Run: go run deep_copy.go
+//
+// Generic deep-copy libraries walk the value with reflect:
+// every field, every slice element, every nested struct is inspected at runtime.
+// A hand-written Clone() knows the layout at compile time:
+//   - one allocation per nested slice
+//   - a bulk copy() for slices of plain values like []Point
+// Same result, very different cost.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+type Polygon struct {
+	ID     int
+	Name   string
+	Points []Point
+	Tags   []string
+	Holes  [][]Point
+}
+
+// Hand-written clone (what you'd write or generate)
+func (p *Polygon) Clone() Polygon {
+	c := *p
+	c.Points = make([]Point, len(p.Points))
+	copy(c.Points, p.Points)  // Point has no pointers: bulk copy is a deep copy
+	c.Tags = make([]string, len(p.Tags))
+	copy(c.Tags, p.Tags)  // Strings are immutable: sharing bytes is safe
+	c.Holes = make([][]Point, len(p.Holes))
+	for i, h := range p.Holes {
+		c.Holes[i] = make([]Point, len(h))
+		copy(c.Holes[i], h)
+	}
+	return c
+}
+
+// Reflection-based deep copy (what a generic library does)
+func deepCopy[T any](src T) T {
+	var dst T
+	copyValue(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(src))
+	return dst
+}
+
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			copyValue(dst.Field(i), src.Field(i))
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			copyValue(s.Index(i), src.Index(i))
+		}
+		dst.Set(s)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		p := reflect.New(src.Elem().Type())
+		copyValue(p.Elem(), src.Elem())
+		dst.Set(p)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			v := reflect.New(iter.Value().Type()).Elem()
+			copyValue(v, iter.Value())
+			m.SetMapIndex(iter.Key(), v)
+		}
+		dst.Set(m)
+	default:
+		dst.Set(src)
+	}
+}
+
+func makePolygon() Polygon {
+	p := Polygon{
+		ID:     42,
+		Name:   "region",
+		Points: make([]Point, 64),
+		Tags:   []string{"land", "north", "surveyed", "v2"},
+		Holes:  make([][]Point, 4),
+	}
+	for i := range p.Points {
+		p.Points[i] = Point{X: i, Y: -i}
+	}
+	for i := range p.Holes {
+		p.Holes[i] = make([]Point, 8)
+		for j := range p.Holes[i] {
+			p.Holes[i][j] = Point{X: i, Y: j}
+		}
+	}
+	return p
+}
+
+// Global to prevent optimizer from eliminating copies
+var gPoly Polygon
+
+func benchmarkReflectCopy(src Polygon, copies int) (time.Duration, uint64) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for i := 0; i < copies; i++ {
+		gPoly = deepCopy(src)
+	}
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs
+}
+
+func benchmarkManualClone(src Polygon, copies int) (time.Duration, uint64) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for i := 0; i < copies; i++ {
+		gPoly = src.Clone()
+	}
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs
+}
+
+func main() {
+	const copies = 100000
+
+	src := makePolygon()
+
+	fmt.Println("Benchmarking Go deep copy: reflection vs hand-written Clone()")
+	fmt.Printf("Copies: %d\n", copies)
+	fmt.Printf("Polygon: %d points, %d tags, %d holes of %d points\n\n",
+		len(src.Points), len(src.Tags), len(src.Holes), len(src.Holes[0]))
+
+	// Sanity check: both produce an equal, independent copy
+	r, m := deepCopy(src), src.Clone()
+	if !reflect.DeepEqual(r, src) || !reflect.DeepEqual(m, src) {
+		panic("copy mismatch")
+	}
+	r.Points[0].X, m.Points[0].X = -1, -1
+	if src.Points[0].X == -1 {
+		panic("copy aliases source")
+	}
+
+	// Warm up
+	benchmarkReflectCopy(src, 1000)
+	benchmarkManualClone(src, 1000)
+
+	// Benchmark reflection copy
+	reflectTime, reflectAllocs := benchmarkReflectCopy(src, copies)
+	reflectPerCopy := reflectTime.Nanoseconds() / int64(copies)
+
+	fmt.Println("Reflection deep copy (generic library style):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(reflectTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per copy: %d ns\n", reflectPerCopy)
+	fmt.Printf("  Allocs per copy: %.1f\n\n", float64(reflectAllocs)/float64(copies))
+
+	// Benchmark manual clone
+	manualTime, manualAllocs := benchmarkManualClone(src, copies)
+	manualPerCopy := manualTime.Nanoseconds() / int64(copies)
+
+	fmt.Println("Hand-written Clone() (compile-time layout):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(manualTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per copy: %d ns\n", manualPerCopy)
+	fmt.Printf("  Allocs per copy: %.1f\n\n", float64(manualAllocs)/float64(copies))
+
+	// Calculate speedup
+	speedup := float64(reflectTime) / float64(manualTime)
+	fmt.Printf("Speedup: %.2fx faster for hand-written clone\n", speedup)
+	fmt.Println("\nConclusion: Reflection pays per field and per element at runtime.")
+	fmt.Println("A clone written against the concrete type copies []Point in bulk.")
+}
@@ -0,0 +1,136 @@
+// Benchmark 11: Devirtualization, visible vs opaque concrete types
+// Run: go run devirtualize.go
+// To see devirtualization decisions: go run -gcflags="-m" devirtualize.go 2>&1 | grep devirtualizing
+//
+// Library authors often export an interface and return it from constructors:
+//   func NewShape(...) Shape
+// Callers then only ever see Shape. The compiler cannot prove the dynamic type,
+// so every call stays an itab dispatch.
+// When the concrete type is visible where the call happens, the Go compiler
+// can devirtualize: rewrite the interface call as a direct (inlinable) call.
+//
+// Note: the compiler does not look at exportedness itself.
+// What matters is whether the concrete type is provable at the call site.
+// Exported interfaces returned from other packages usually make it unprovable.
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Exported interface: concrete type hidden behind a constructor
+type Shape interface {
+	Area() float64
+}
+
+// Unexported interface: used only where the concrete type is in view
+type shape interface {
+	area() float64
+}
+
+type Circle struct {
+	Radius int
+}
+
+func (c Circle) Area() float64 {
+	return 3.14159 * float64(c.Radius*c.Radius)
+}
+
+func (c Circle) area() float64 {
+	return 3.14159 * float64(c.Radius*c.Radius)
+}
+
+// Public constructor (like a library API); noinline keeps the type opaque,
+// as it would be when the caller is in another package
+//
+//go:noinline
+func NewShape(radius int) Shape {
+	return Circle{Radius: radius}
+}
+
+// Benchmark dispatch through the exported interface (type unknown to caller)
+func benchmarkOpaque(n int, iterations int) time.Duration {
+	shapes := make([]Shape, n)
+	for i := 0; i < n; i++ {
+		shapes[i] = NewShape(i)
+	}
+
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += shapes[i].Area()  // Itab dispatch
+		}
+		// Prevent optimization
+		if sum < 0 {
+			fmt.Println(sum)
+		}
+	}
+
+	return time.Since(start)
+}
+
+// Benchmark the unexported interface with the concrete type in view
+func benchmarkVisible(n int, iterations int) time.Duration {
+	circles := make([]Circle, n)
+	for i := 0; i < n; i++ {
+		circles[i] = Circle{Radius: i}
+	}
+
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			var s shape = circles[i]  // Concrete type provable here
+			sum += s.area()           // Devirtualized to Circle.area
+		}
+		// Prevent optimization
+		if sum < 0 {
+			fmt.Println(sum)
+		}
+	}
+
+	return time.Since(start)
+}
+
+func main() {
+	const n = 10000000  // 10 million calls
+	const iterations = 10
+
+	fmt.Println("Benchmarking Go devirtualization: opaque vs visible concrete type")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Iterations: %d\n", iterations)
+	fmt.Printf("Total calls: %d\n\n", n*iterations)
+
+	// Warm up
+	benchmarkOpaque(1000, 10)
+	benchmarkVisible(1000, 10)
+
+	// Benchmark opaque exported interface
+	opaqueTime := benchmarkOpaque(n, iterations)
+	opaquePerCall := float64(opaqueTime.Nanoseconds()) / float64(n*iterations)
+
+	fmt.Println("Exported Shape from NewShape() (type opaque to caller):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(opaqueTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per call: %.2f ns\n\n", opaquePerCall)
+
+	// Benchmark visible unexported interface
+	visibleTime := benchmarkVisible(n, iterations)
+	visiblePerCall := float64(visibleTime.Nanoseconds()) / float64(n*iterations)
+
+	fmt.Println("Unexported shape with concrete type in view (devirtualized):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(visibleTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per call: %.2f ns\n\n", visiblePerCall)
+
+	// Calculate speedup
+	speedup := float64(opaqueTime) / float64(visibleTime)
+	fmt.Printf("Speedup: %.2fx faster when the compiler can devirtualize\n", speedup)
+	fmt.Println("\nConclusion: Returning an exported interface hides the concrete type")
+	fmt.Println("from callers, so their calls cannot be devirtualized or inlined.")
+	fmt.Println("Returning the concrete type (\"accept interfaces, return structs\")")
+	fmt.Println("keeps that optimization available to every caller.")
+}
@@ -0,0 +1,168 @@
+// Benchmark 29: Multi-format encoder, Encoder interface per field vs format switch per record
+// Run: go run encoder_dispatch.go
+//
+// Serializers that support several formats often use the strategy pattern:
+// one Encoder implementation per format, called for every piece of a record:
+//   enc.Begin(buf); enc.Field(buf, "x", p.X); enc.Field(buf, "y", p.Y); enc.End(buf)
+// That is four interface calls per Point, none of them inlinable.
+// The switch design picks the format once per record:
+//   switch format { case CSV: ...; case JSON: ... }
+// and each case appends the whole record with direct, inlinable code.
+// Both encoders write into a reused buffer, so allocation is not a factor.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Strategy design (interface call per field)
+type Encoder interface {
+	Begin(buf []byte) []byte
+	Field(buf []byte, name string, v int) []byte
+	End(buf []byte) []byte
+}
+
+type CSVEncoder struct{}
+
+func (CSVEncoder) Begin(buf []byte) []byte { return buf }
+func (CSVEncoder) Field(buf []byte, name string, v int) []byte {
+	if n := len(buf); n > 0 && buf[n-1] != '\n' {
+		buf = append(buf, ',')
+	}
+	return strconv.AppendInt(buf, int64(v), 10)
+}
+func (CSVEncoder) End(buf []byte) []byte { return append(buf, '\n') }
+
+type JSONEncoder struct{}
+
+func (JSONEncoder) Begin(buf []byte) []byte { return append(buf, '{') }
+func (JSONEncoder) Field(buf []byte, name string, v int) []byte {
+	if buf[len(buf)-1] != '{' {
+		buf = append(buf, ',')
+	}
+	buf = append(buf, '"')
+	buf = append(buf, name...)
+	buf = append(buf, '"', ':')
+	return strconv.AppendInt(buf, int64(v), 10)
+}
+func (JSONEncoder) End(buf []byte) []byte { return append(buf, '}', '\n') }
+
+func encodeInterface(buf []byte, points []Point, enc Encoder) []byte {
+	for i := range points {
+		buf = enc.Begin(buf)
+		buf = enc.Field(buf, "x", points[i].X)
+		buf = enc.Field(buf, "y", points[i].Y)
+		buf = enc.End(buf)
+	}
+	return buf
+}
+
+// Switch design (format enum, one branch per record)
+type Format int
+
+const (
+	FormatCSV Format = iota
+	FormatJSON
+)
+
+func encodeSwitch(buf []byte, points []Point, format Format) []byte {
+	for i := range points {
+		p := points[i]
+		switch format {
+		case FormatCSV:
+			buf = strconv.AppendInt(buf, int64(p.X), 10)
+			buf = append(buf, ',')
+			buf = strconv.AppendInt(buf, int64(p.Y), 10)
+			buf = append(buf, '\n')
+		case FormatJSON:
+			buf = append(buf, `{"x":`...)
+			buf = strconv.AppendInt(buf, int64(p.X), 10)
+			buf = append(buf, `,"y":`...)
+			buf = strconv.AppendInt(buf, int64(p.Y), 10)
+			buf = append(buf, '}', '\n')
+		}
+	}
+	return buf
+}
+
+// Global to prevent optimizer from eliminating encoding
+var gLen int
+
+func benchmarkInterface(buf []byte, points []Point, enc Encoder, iterations int) (time.Duration, []byte) {
+	start := time.Now()
+	for iter := 0; iter < iterations; iter++ {
+		buf = encodeInterface(buf[:0], points, enc)
+		gLen += len(buf)
+	}
+	return time.Since(start), buf
+}
+
+func benchmarkSwitch(buf []byte, points []Point, format Format, iterations int) (time.Duration, []byte) {
+	start := time.Now()
+	for iter := 0; iter < iterations; iter++ {
+		buf = encodeSwitch(buf[:0], points, format)
+		gLen += len(buf)
+	}
+	return time.Since(start), buf
+}
+
+func main() {
+	const n = 100000  // Records per encode
+	const iterations = 100
+
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{X: i, Y: n - i}
+	}
+
+	encoders := []Encoder{CSVEncoder{}, JSONEncoder{}}
+	formats := []Format{FormatCSV, FormatJSON}
+	names := []string{"CSV", "JSON"}
+
+	fmt.Println("Benchmarking Go multi-format encoder: Encoder interface vs format switch")
+	fmt.Printf("Records: %d\n", n)
+	fmt.Printf("Iterations: %d\n\n", iterations)
+
+	ibuf := make([]byte, 0, 32*n)
+	sbuf := make([]byte, 0, 32*n)
+
+	// Warm up
+	for f := range formats {
+		_, ibuf = benchmarkInterface(ibuf, points, encoders[f], 2)
+		_, sbuf = benchmarkSwitch(sbuf, points, formats[f], 2)
+	}
+
+	for f := range formats {
+		var interfaceTime, switchTime time.Duration
+		interfaceTime, ibuf = benchmarkInterface(ibuf, points, encoders[f], iterations)
+		switchTime, sbuf = benchmarkSwitch(sbuf, points, formats[f], iterations)
+
+		// Both encoders must produce the same bytes
+		if !bytes.Equal(ibuf, sbuf) {
+			panic("encoders disagree for " + names[f])
+		}
+
+		interfacePer := float64(interfaceTime.Nanoseconds()) / float64(n*iterations)
+		switchPer := float64(switchTime.Nanoseconds()) / float64(n*iterations)
+
+		fmt.Printf("%s (%d bytes per encode):\n", names[f], len(sbuf))
+		fmt.Printf("  Encoder interface (4 calls/record): %.2f ns per record\n", interfacePer)
+		fmt.Printf("  Format switch (1 branch/record):    %.2f ns per record\n", switchPer)
+		fmt.Printf("  Overhead: %.2f ns per record\n", interfacePer-switchPer)
+		fmt.Printf("  Speedup: %.2fx faster for switch\n\n", float64(interfaceTime)/float64(switchTime))
+	}
+
+	fmt.Println("Note: Both encoders format digits with strconv.AppendInt; the difference is dispatch")
+	fmt.Println("plus the per-field separator checks the generic Field method needs.")
+	fmt.Println("\nConclusion: A strategy interface dispatched per field pays several")
+	fmt.Println("indirect calls per record. Switching on the format once per record")
+	fmt.Println("lets each case encode the whole Point in straight-line code.")
+}
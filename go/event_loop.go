@@ -0,0 +1,207 @@
+// Benchmark 13: Game/simulation tick, interface entities vs tagged union
+// Run: go run event_loop.go
+//
+// The classic OO design for a simulation tick:
+//   for _, e := range entities { e.Update(dt) }   // []Updater
+// Each entity is its own heap object behind an interface, so every tick pays:
+//   - an indirect call per entity (itab dispatch)
+//   - a pointer dereference per entity (scattered objects)
+// The data-oriented alternative stores one Entity struct type inline in a slice
+// and switches on a Kind field. Same behavior, contiguous memory, direct code.
+// This is the structs-vs-classes argument in a single loop.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+	"unsafe"
+)
+
+// Interface design (one type per entity kind)
+type Updater interface {
+	Update(dt float64)
+}
+
+type Player struct {
+	X, Y, VX, VY float64
+}
+
+func (p *Player) Update(dt float64) {
+	p.X += p.VX * dt
+	p.Y += p.VY * dt
+}
+
+type Enemy struct {
+	X, Y, VX, VY float64
+	HP           float64
+}
+
+func (e *Enemy) Update(dt float64) {
+	e.X += e.VX * dt
+	e.Y += e.VY * dt
+	e.HP -= 0.01 * dt
+}
+
+type Projectile struct {
+	X, Y, VX, VY float64
+	TTL          float64
+}
+
+func (p *Projectile) Update(dt float64) {
+	p.X += p.VX * dt
+	p.Y += p.VY * dt
+	p.TTL -= dt
+}
+
+// Data-oriented design (one struct type, tagged by Kind)
+type Kind uint8
+
+const (
+	KindPlayer Kind = iota
+	KindEnemy
+	KindProjectile
+)
+
+type Entity struct {
+	Kind         Kind
+	X, Y, VX, VY float64
+	Aux          float64  // HP for enemies, TTL for projectiles
+}
+
+func (e *Entity) Update(dt float64) {
+	e.X += e.VX * dt
+	e.Y += e.VY * dt
+	switch e.Kind {
+	case KindEnemy:
+		e.Aux -= 0.01 * dt
+	case KindProjectile:
+		e.Aux -= dt
+	}
+}
+
+// Global to prevent optimizer from eliminating updates
+var gSum float64
+
+// Same mixed sequence of kinds for both designs
+func makeKinds(n int) []Kind {
+	r := rand.New(rand.NewSource(1))
+	kinds := make([]Kind, n)
+	for i := range kinds {
+		kinds[i] = Kind(r.Intn(3))
+	}
+	return kinds
+}
+
+// Benchmark []Updater (dispatch + pointer chasing per entity)
+func benchmarkInterface(kinds []Kind, ticks int, dt float64) time.Duration {
+	entities := make([]Updater, len(kinds))
+	for i, k := range kinds {
+		f := float64(i)
+		switch k {
+		case KindPlayer:
+			entities[i] = &Player{X: f, Y: f, VX: 1, VY: 1}
+		case KindEnemy:
+			entities[i] = &Enemy{X: f, Y: f, VX: -1, VY: 1, HP: 100}
+		case KindProjectile:
+			entities[i] = &Projectile{X: f, Y: f, VX: 5, VY: 5, TTL: 1000}
+		}
+	}
+
+	start := time.Now()
+
+	for t := 0; t < ticks; t++ {
+		for _, e := range entities {
+			e.Update(dt)  // Interface call
+		}
+	}
+
+	elapsed := time.Since(start)
+	if p, ok := entities[0].(*Player); ok {
+		gSum = p.X
+	}
+	return elapsed
+}
+
+// Benchmark []Entity (kind switch on contiguous values)
+func benchmarkTagged(kinds []Kind, ticks int, dt float64) time.Duration {
+	entities := make([]Entity, len(kinds))
+	for i, k := range kinds {
+		f := float64(i)
+		switch k {
+		case KindPlayer:
+			entities[i] = Entity{Kind: k, X: f, Y: f, VX: 1, VY: 1}
+		case KindEnemy:
+			entities[i] = Entity{Kind: k, X: f, Y: f, VX: -1, VY: 1, Aux: 100}
+		case KindProjectile:
+			entities[i] = Entity{Kind: k, X: f, Y: f, VX: 5, VY: 5, Aux: 1000}
+		}
+	}
+
+	start := time.Now()
+
+	for t := 0; t < ticks; t++ {
+		for i := range entities {
+			entities[i].Update(dt)  // Direct call (inlinable)
+		}
+	}
+
+	elapsed := time.Since(start)
+	gSum = entities[0].X
+	return elapsed
+}
+
+func report(name string, elapsed time.Duration, n, ticks int) {
+	fmt.Println(name)
+	fmt.Printf("  Total time: %.2f ms\n", float64(elapsed.Microseconds())/1000.0)
+	fmt.Printf("  Time per tick: %.1f us\n", float64(elapsed.Microseconds())/float64(ticks))
+	fmt.Printf("  Time per entity: %.2f ns\n\n", float64(elapsed.Nanoseconds())/float64(n*ticks))
+}
+
+func main() {
+	const n = 100000  // Entities
+	const ticks = 1000
+	const dt = 1.0 / 60.0
+
+	kinds := makeKinds(n)
+	sorted := slices.Clone(kinds)
+	slices.Sort(sorted)
+
+	fmt.Println("Benchmarking Go simulation tick: []Updater vs tagged []Entity")
+	fmt.Printf("Entities: %d (mixed: player, enemy, projectile)\n", n)
+	fmt.Printf("Ticks: %d\n", ticks)
+	fmt.Printf("Entity size: %d bytes\n\n", int(unsafe.Sizeof(Entity{})))
+
+	// Warm up
+	benchmarkInterface(kinds[:1000], 10, dt)
+	benchmarkTagged(kinds[:1000], 10, dt)
+
+	// Kinds interleaved at random (worst case for branch prediction)
+	interfaceTime := benchmarkInterface(kinds, ticks, dt)
+	report("Interface collection ([]Updater - OO style), mixed order:", interfaceTime, n, ticks)
+
+	taggedTime := benchmarkTagged(kinds, ticks, dt)
+	report("Tagged union ([]Entity + kind switch), mixed order:", taggedTime, n, ticks)
+
+	// Kinds grouped together (common data-oriented practice)
+	interfaceSortedTime := benchmarkInterface(sorted, ticks, dt)
+	report("Interface collection ([]Updater - OO style), grouped by kind:", interfaceSortedTime, n, ticks)
+
+	taggedSortedTime := benchmarkTagged(sorted, ticks, dt)
+	report("Tagged union ([]Entity + kind switch), grouped by kind:", taggedSortedTime, n, ticks)
+
+	// Calculate speedup
+	fmt.Printf("Per-tick difference (mixed): %.1f us\n",
+		float64((interfaceTime-taggedTime).Microseconds())/float64(ticks))
+	fmt.Printf("Per-tick difference (grouped): %.1f us\n",
+		float64((interfaceSortedTime-taggedSortedTime).Microseconds())/float64(ticks))
+	fmt.Printf("Speedup (mixed): %.2fx faster for tagged union\n", float64(interfaceTime)/float64(taggedTime))
+	fmt.Printf("Speedup (grouped): %.2fx faster for tagged union\n", float64(interfaceSortedTime)/float64(taggedSortedTime))
+	fmt.Println("\nConclusion: The OO tick pays dispatch and pointer chasing per entity.")
+	fmt.Println("The data-oriented tick streams contiguous structs through direct code.")
+	fmt.Println("Mixed kinds cost both designs branch mispredictions; grouping by kind")
+	fmt.Println("removes them, and the tagged union's layout advantage shows through.")
+	fmt.Println("C++ class hierarchies push you to the first. Go structs make the second easy.")
+}
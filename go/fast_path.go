@@ -0,0 +1,137 @@
+// Benchmark 21: Concrete fast path via type assertion vs always dispatching
+// Run: go run fast_path.go
+//
+// When one concrete type dominates an interface collection,
+// a cheap type check can route the common case to direct code:
+//   if c, ok := s.(Circle); ok { sum += c.Area() } else { sum += s.Area() }
+// The assertion compares one itab pointer; on a hit, Area() is a direct,
+// inlinable call. Misses fall back to normal dispatch.
+// The standard library does this in places (io.Copy checks for WriterTo/ReaderFrom).
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Interface (dynamic dispatch)
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Radius float64
+}
+
+func (c Circle) Area() float64 {
+	return 3.14159 * c.Radius * c.Radius
+}
+
+type Square struct {
+	Side float64
+}
+
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+type Triangle struct {
+	Base, Height float64
+}
+
+func (t Triangle) Area() float64 {
+	return 0.5 * t.Base * t.Height
+}
+
+// Build a collection where Circle makes up the given fraction
+func makeShapes(n int, circleFraction float64) []Shape {
+	r := rand.New(rand.NewSource(1))
+	shapes := make([]Shape, n)
+	for i := range shapes {
+		f := float64(i%100 + 1)
+		switch x := r.Float64(); {
+		case x < circleFraction:
+			shapes[i] = Circle{Radius: f}
+		case x < circleFraction+(1-circleFraction)/2:
+			shapes[i] = Square{Side: f}
+		default:
+			shapes[i] = Triangle{Base: f, Height: f}
+		}
+	}
+	return shapes
+}
+
+// Benchmark always dispatching through the interface
+func benchmarkDispatch(shapes []Shape, iterations int) (time.Duration, float64) {
+	var total float64
+
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		sum := 0.0
+		for _, s := range shapes {
+			sum += s.Area()  // Interface call
+		}
+		total = sum
+	}
+
+	return time.Since(start), total
+}
+
+// Benchmark the Circle fast path with interface fallback
+func benchmarkFastPath(shapes []Shape, iterations int) (time.Duration, float64) {
+	var total float64
+
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		sum := 0.0
+		for _, s := range shapes {
+			if c, ok := s.(Circle); ok {
+				sum += c.Area()  // Direct call (inlined)
+			} else {
+				sum += s.Area()  // Fallback: interface call
+			}
+		}
+		total = sum
+	}
+
+	return time.Since(start), total
+}
+
+func main() {
+	const n = 1000000  // 1 million shapes
+	const iterations = 20
+
+	fmt.Println("Benchmarking Go concrete fast path vs always dispatching")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Iterations: %d\n\n", iterations)
+
+	// Warm up
+	warm := makeShapes(1000, 0.9)
+	benchmarkDispatch(warm, 10)
+	benchmarkFastPath(warm, 10)
+
+	fmt.Println("Time per element (ns) by share of Circle in the collection:")
+	fmt.Printf("  %-8s  %10s %10s  %s\n", "Circles", "Dispatch", "Fast path", "Speedup")
+
+	for _, fraction := range []float64{0.5, 0.9, 0.99, 1.0} {
+		shapes := makeShapes(n, fraction)
+
+		dispatchTime, dispatchSum := benchmarkDispatch(shapes, iterations)
+		fastTime, fastSum := benchmarkFastPath(shapes, iterations)
+		if dispatchSum != fastSum {
+			panic("results differ")
+		}
+
+		dispatchPer := float64(dispatchTime.Nanoseconds()) / float64(n*iterations)
+		fastPer := float64(fastTime.Nanoseconds()) / float64(n*iterations)
+		fmt.Printf("  %6.0f%%   %10.2f %10.2f  %.2fx\n", fraction*100, dispatchPer, fastPer, dispatchPer/fastPer)
+	}
+
+	fmt.Println("\nConclusion: When one type dominates, a single type assertion")
+	fmt.Println("turns most interface calls into inlined direct calls.")
+	fmt.Println("With a mixed collection, the extra branch mispredicts and the gain shrinks.")
+}
@@ -0,0 +1,134 @@
+// Benchmark 30: Sorted view via index table vs physically reordered data
+// Run: go run index_table.go
+//
+// "Sort an index, not the data": to view []Point sorted by Y without moving it,
+// sort an []int of positions and read points[idx[i]].
+// The data stays put, but traversal order no longer matches memory order:
+//   - direct traversal: sequential, prefetcher-friendly
+//   - through the index table: one random jump per element (cache miss when large)
+//   - reordered copy: pay once to copy points into sorted order, then traverse sequentially
+// The data is sized well past the last-level cache so the locality cost shows.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+	"unsafe"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Global to prevent optimizer from eliminating traversals
+var gSum int
+
+func sumDirect(points []Point) int {
+	sum := 0
+	for i := range points {
+		sum += points[i].X + points[i].Y
+	}
+	return sum
+}
+
+func sumIndexed(points []Point, idx []int) int {
+	sum := 0
+	for _, j := range idx {
+		sum += points[j].X + points[j].Y  // Random jump into points
+	}
+	return sum
+}
+
+// Copy points into the order given by idx
+func reorder(points []Point, idx []int) []Point {
+	out := make([]Point, len(idx))
+	for i, j := range idx {
+		out[i] = points[j]
+	}
+	return out
+}
+
+func benchmark(iterations int, traverse func() int) (time.Duration, int) {
+	sum := 0
+	start := time.Now()
+	for iter := 0; iter < iterations; iter++ {
+		sum = traverse()
+	}
+	elapsed := time.Since(start)
+	gSum = sum
+	return elapsed, sum
+}
+
+func main() {
+	const n = 4000000  // 4 million points (~61 MB)
+	const iterations = 10
+
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{X: i, Y: r.Intn(n)}  // Y unrelated to memory order
+	}
+
+	// Sorted view by Y: sort positions, not points
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortFunc(idx, func(a, b int) int { return points[a].Y - points[b].Y })
+
+	fmt.Println("Benchmarking Go sorted views: index table vs reordered data")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Iterations: %d\n", iterations)
+	fmt.Printf("Data size: %d MB\n\n", n*int(unsafe.Sizeof(Point{}))>>20)
+
+	// Physically reorder once (timed separately)
+	start := time.Now()
+	sorted := reorder(points, idx)
+	reorderTime := time.Since(start)
+
+	// Warm up
+	benchmark(1, func() int { return sumDirect(points) })
+	benchmark(1, func() int { return sumIndexed(points, idx) })
+	benchmark(1, func() int { return sumDirect(sorted) })
+
+	directTime, ds := benchmark(iterations, func() int { return sumDirect(points) })
+	indexedTime, is := benchmark(iterations, func() int { return sumIndexed(points, idx) })
+	sortedTime, ss := benchmark(iterations, func() int { return sumDirect(sorted) })
+
+	// All three visit the same points
+	if ds != is || ds != ss {
+		panic("sums differ")
+	}
+
+	perElement := func(d time.Duration) float64 {
+		return float64(d.Nanoseconds()) / float64(n*iterations)
+	}
+
+	fmt.Println("Direct traversal (memory order, unsorted):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(directTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per element: %.2f ns\n\n", perElement(directTime))
+
+	fmt.Println("Index table (sorted view, points[idx[i]]):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(indexedTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per element: %.2f ns\n\n", perElement(indexedTime))
+
+	fmt.Println("Reordered copy (sorted view, sequential):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(sortedTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per element: %.2f ns\n", perElement(sortedTime))
+	fmt.Printf("  One-time reorder cost: %.2f ms\n\n", float64(reorderTime.Microseconds())/1000.0)
+
+	// Traversals of the sorted view after which the copy has paid for itself
+	saved := indexedTime/iterations - sortedTime/iterations
+	if saved > 0 {
+		fmt.Printf("Break-even: reordering pays off after %.1f traversals\n", float64(reorderTime)/float64(saved))
+	}
+	fmt.Printf("Indirection penalty: %.2fx slower than the reordered copy\n", float64(indexedTime)/float64(sortedTime))
+	fmt.Println("\nNote: The reorder itself walks points through the index once,")
+	fmt.Println("so its cost is roughly one indexed traversal plus the copy.")
+	fmt.Println("\nConclusion: An index table avoids moving data but turns every")
+	fmt.Println("traversal into random access. If the sorted view is read more than")
+	fmt.Println("a few times, physically reordering the []Point is cheaper overall.")
+}
@@ -0,0 +1,162 @@
+// Benchmark 27: Interface dispatch vs method set size
+// Run: go run large_methodset.go
+//
+// Does a type with many methods make interface calls slower?
+// An itab holds one function pointer per method of the INTERFACE, not of the type:
+//   - Shape{Area} on a 1-method type and on a 17-method type: same 1-entry itab
+//   - a 17-method interface: a bigger itab, but still one load at a fixed offset
+// So dispatch should be method-set neutral. This checks that on real hardware,
+// with both a small and a large interface over the same large type.
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Small interface (1-entry itab regardless of the concrete type)
+type Shape interface {
+	Area() float64
+}
+
+// Large interface (17-entry itab)
+type BigShape interface {
+	Area() float64
+	M00() float64
+	M01() float64
+	M02() float64
+	M03() float64
+	M04() float64
+	M05() float64
+	M06() float64
+	M07() float64
+	M08() float64
+	M09() float64
+	M10() float64
+	M11() float64
+	M12() float64
+	M13() float64
+	M14() float64
+	M15() float64
+}
+
+// Concrete type with a single method
+type SmallCircle struct {
+	Radius int
+}
+
+func (c SmallCircle) Area() float64 {
+	return 3.14159 * float64(c.Radius*c.Radius)
+}
+
+// Concrete type with 17 methods
+type BigCircle struct {
+	Radius int
+}
+
+func (c BigCircle) Area() float64 {
+	return 3.14159 * float64(c.Radius*c.Radius)
+}
+
+func (c BigCircle) M00() float64 { return float64(c.Radius + 0) }
+func (c BigCircle) M01() float64 { return float64(c.Radius + 1) }
+func (c BigCircle) M02() float64 { return float64(c.Radius + 2) }
+func (c BigCircle) M03() float64 { return float64(c.Radius + 3) }
+func (c BigCircle) M04() float64 { return float64(c.Radius + 4) }
+func (c BigCircle) M05() float64 { return float64(c.Radius + 5) }
+func (c BigCircle) M06() float64 { return float64(c.Radius + 6) }
+func (c BigCircle) M07() float64 { return float64(c.Radius + 7) }
+func (c BigCircle) M08() float64 { return float64(c.Radius + 8) }
+func (c BigCircle) M09() float64 { return float64(c.Radius + 9) }
+func (c BigCircle) M10() float64 { return float64(c.Radius + 10) }
+func (c BigCircle) M11() float64 { return float64(c.Radius + 11) }
+func (c BigCircle) M12() float64 { return float64(c.Radius + 12) }
+func (c BigCircle) M13() float64 { return float64(c.Radius + 13) }
+func (c BigCircle) M14() float64 { return float64(c.Radius + 14) }
+func (c BigCircle) M15() float64 { return float64(c.Radius + 15) }
+
+// Benchmark dispatch through Shape
+func benchmarkShape(shapes []Shape, iterations int) time.Duration {
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		sum := 0.0
+		for i := range shapes {
+			sum += shapes[i].Area()  // Interface call (1-entry itab)
+		}
+		// Prevent optimization
+		if sum < 0 {
+			fmt.Println(sum)
+		}
+	}
+
+	return time.Since(start)
+}
+
+// Benchmark dispatch through BigShape
+func benchmarkBigShape(shapes []BigShape, iterations int) time.Duration {
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		sum := 0.0
+		for i := range shapes {
+			sum += shapes[i].Area()  // Interface call (17-entry itab)
+		}
+		// Prevent optimization
+		if sum < 0 {
+			fmt.Println(sum)
+		}
+	}
+
+	return time.Since(start)
+}
+
+func main() {
+	const n = 10000000  // 10 million calls
+	const iterations = 10
+
+	small := make([]Shape, n)
+	big := make([]Shape, n)
+	bigIface := make([]BigShape, n)
+	for i := 0; i < n; i++ {
+		small[i] = SmallCircle{Radius: i}
+		big[i] = BigCircle{Radius: i}
+		bigIface[i] = BigCircle{Radius: i}
+	}
+
+	fmt.Println("Benchmarking Go interface dispatch vs method set size")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Iterations: %d\n", iterations)
+	fmt.Printf("Total calls: %d\n\n", n*iterations)
+
+	// Warm up
+	benchmarkShape(small[:1000], 10)
+	benchmarkShape(big[:1000], 10)
+	benchmarkBigShape(bigIface[:1000], 10)
+
+	smallTime := benchmarkShape(small, iterations)
+	bigTime := benchmarkShape(big, iterations)
+	bigIfaceTime := benchmarkBigShape(bigIface, iterations)
+
+	perCall := func(d time.Duration) float64 {
+		return float64(d.Nanoseconds()) / float64(n*iterations)
+	}
+
+	fmt.Println("Shape (1 method) holding SmallCircle (1 method):")
+	fmt.Printf("  Time per call: %.2f ns\n\n", perCall(smallTime))
+
+	fmt.Println("Shape (1 method) holding BigCircle (17 methods):")
+	fmt.Printf("  Time per call: %.2f ns\n\n", perCall(bigTime))
+
+	fmt.Println("BigShape (17 methods) holding BigCircle (17 methods):")
+	fmt.Printf("  Time per call: %.2f ns\n\n", perCall(bigIfaceTime))
+
+	fmt.Printf("Large type vs small type: %.2fx\n", float64(bigTime)/float64(smallTime))
+	fmt.Printf("Large interface vs small interface: %.2fx\n", float64(bigIfaceTime)/float64(bigTime))
+	fmt.Println("\nConclusion: Ratios within run-to-run noise (~10%) of 1.00x mean")
+	fmt.Println("method-set size is dispatch-neutral.")
+	fmt.Println("An interface call is one load from the itab at a fixed offset and an")
+	fmt.Println("indirect call, however many methods the type or interface has.")
+	fmt.Println("Interface size is an API design question, not a performance one.")
+}
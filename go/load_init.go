@@ -0,0 +1,179 @@
+// Benchmark 22: Loading a []Point from a binary file vs generating it in memory
+// Run: go run load_init.go
+//
+// Cold start often means reading a large dataset of fixed-size records.
+// How it is decoded decides whether loading is I/O-bound or allocation-bound:
+//   - binary.Read per record: reflection on the destination, allocations per call
+//   - streaming decode: one buffered reader, fixed-size records decoded by hand
+//     straight into a preallocated []Point (no per-record allocation)
+// Generating the same slice in memory is the lower bound.
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"time"
+)
+
+type Point struct {
+	X, Y int64
+}
+
+const recordSize = 16  // Two little-endian int64s
+
+// Global to prevent optimizer from eliminating loads
+var gSum int64
+
+func writeFile(path string, n int) {
+	f, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriterSize(f, 1<<20)
+	var rec [recordSize]byte
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(rec[0:8], uint64(i))
+		binary.LittleEndian.PutUint64(rec[8:16], uint64(-i))
+		w.Write(rec[:])
+	}
+	if err := w.Flush(); err != nil {
+		panic(err)
+	}
+}
+
+type result struct {
+	elapsed time.Duration
+	allocs  uint64
+	points  []Point
+}
+
+func measure(load func() []Point) result {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	points := load()
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return result{elapsed, after.Mallocs - before.Mallocs, points}
+}
+
+// Generate in memory (no I/O, no decode)
+func generate(n int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{X: int64(i), Y: int64(-i)}
+	}
+	return points
+}
+
+// binary.Read one record at a time, appending to a growing slice
+func loadBinaryRead(path string) []Point {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReaderSize(f, 1<<20)
+	var points []Point
+	for {
+		var p Point
+		if err := binary.Read(r, binary.LittleEndian, &p); err == io.EOF {
+			break
+		} else if err != nil {
+			panic(err)
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// Stream fixed-size records into a slice preallocated from the file size
+func loadStreaming(path string) []Point {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		panic(err)
+	}
+	points := make([]Point, info.Size()/recordSize)
+
+	r := bufio.NewReaderSize(f, 1<<20)
+	var rec [recordSize]byte
+	for i := range points {
+		if _, err := io.ReadFull(r, rec[:]); err != nil {
+			panic(err)
+		}
+		points[i] = Point{
+			X: int64(binary.LittleEndian.Uint64(rec[0:8])),
+			Y: int64(binary.LittleEndian.Uint64(rec[8:16])),
+		}
+	}
+	return points
+}
+
+func report(name string, n int, r result) {
+	mb := float64(n*recordSize) / (1 << 20)
+	fmt.Println(name)
+	fmt.Printf("  Total time: %.2f ms\n", float64(r.elapsed.Microseconds())/1000.0)
+	fmt.Printf("  Time per record: %.1f ns\n", float64(r.elapsed.Nanoseconds())/float64(n))
+	fmt.Printf("  Rate: %.0f MB/s (%.1f M records/s)\n", mb/r.elapsed.Seconds(), float64(n)/r.elapsed.Seconds()/1e6)
+	fmt.Printf("  Allocs: %d (%.3f per record)\n\n", r.allocs, float64(r.allocs)/float64(n))
+}
+
+func main() {
+	const n = 5000000  // 5 million records (~76 MB)
+
+	f, err := os.CreateTemp("", "points-*.bin")
+	if err != nil {
+		panic(err)
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	writeFile(path, n)
+
+	fmt.Println("Benchmarking Go []Point loading: binary file vs in-memory generation")
+	fmt.Printf("Records: %d (%d bytes each, %.1f MB)\n\n", n, recordSize, float64(n*recordSize)/(1<<20))
+
+	// Warm up (also pulls the file into the page cache)
+	loadStreaming(path)
+
+	gen := measure(func() []Point { return generate(n) })
+	report("Generate in memory (lower bound):", n, gen)
+
+	naive := measure(func() []Point { return loadBinaryRead(path) })
+	report("binary.Read per record + append:", n, naive)
+
+	stream := measure(func() []Point { return loadStreaming(path) })
+	report("Streaming decode into preallocated []Point:", n, stream)
+
+	// All three must produce the same data
+	for i := range gen.points {
+		if naive.points[i] != gen.points[i] || stream.points[i] != gen.points[i] {
+			panic("decoded data differs")
+		}
+	}
+	gSum = stream.points[n-1].X
+
+	fmt.Printf("Streaming vs binary.Read: %.2fx faster\n", float64(naive.elapsed)/float64(stream.elapsed))
+	fmt.Printf("Streaming vs generation: %.2fx slower\n", float64(stream.elapsed)/float64(gen.elapsed))
+	fmt.Println("\nNote: The file is in the page cache, so this measures decode, not disk.")
+	fmt.Println("\nConclusion: Decode fixed-size records by hand into a slice sized up front.")
+	fmt.Println("Per-record binary.Read turns a memcpy-speed load into an allocation benchmark.")
+}
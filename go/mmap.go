@@ -0,0 +1,114 @@
+//go:build unix
+
+// Benchmark 15: Value slices in mmap'd memory vs the Go heap
+// Run: go run mmap.go   (Unix only)
+//
+// Very large datasets sometimes live outside the Go heap:
+// an anonymous mmap region viewed as a []Point via unsafe.Slice.
+//   - The GC never scans or moves it (Point has no pointers, so this is safe)
+//   - Pages are mapped lazily: the first touch of each page is a page fault
+// Once the pages are resident, it is the same contiguous []Point as any other.
+// This measures both phases (first touch and steady-state traversal)
+// and reports minor page faults from getrusage.
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Minor page faults for this process so far
+func minorFaults() int64 {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		panic(err)
+	}
+	return int64(ru.Minflt)
+}
+
+// Fill every element (first touch of every page)
+func initialize(points []Point) (time.Duration, int64) {
+	faults := minorFaults()
+	start := time.Now()
+
+	for i := range points {
+		points[i] = Point{X: i, Y: i}
+	}
+
+	return time.Since(start), minorFaults() - faults
+}
+
+// Traverse already-resident memory
+func traverse(points []Point, iterations int) (time.Duration, int64) {
+	faults := minorFaults()
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		sum := 0
+		for i := range points {
+			sum += points[i].X + points[i].Y
+		}
+		// Prevent optimization
+		if sum < 0 {
+			fmt.Println(sum)
+		}
+	}
+
+	return time.Since(start), minorFaults() - faults
+}
+
+func report(name string, n, iterations int, initTime time.Duration, initFaults int64, travTime time.Duration, travFaults int64) {
+	fmt.Println(name)
+	fmt.Printf("  First touch: %.2f ms, %.2f ns/element, %d page faults\n",
+		float64(initTime.Microseconds())/1000.0, float64(initTime.Nanoseconds())/float64(n), initFaults)
+	fmt.Printf("  Traversal: %.2f ms, %.2f ns/element, %d page faults\n\n",
+		float64(travTime.Microseconds())/1000.0, float64(travTime.Nanoseconds())/float64(n*iterations), travFaults)
+}
+
+func main() {
+	const n = 10000000  // 10 million points (~160 MB)
+	const iterations = 20
+
+	size := n * int(unsafe.Sizeof(Point{}))
+
+	fmt.Println("Benchmarking Go []Point storage: anonymous mmap vs Go heap")
+	fmt.Printf("Elements: %d (%.1f MB)\n", n, float64(size)/(1<<20))
+	fmt.Printf("Traversal passes: %d\n", iterations)
+	fmt.Printf("Page size: %d bytes\n\n", syscall.Getpagesize())
+
+	// Heap-backed slice
+	heap := make([]Point, n)
+	heapInit, heapInitFaults := initialize(heap)
+	heapTrav, heapTravFaults := traverse(heap, iterations)
+	report("Go heap (make([]Point, n)):", n, iterations, heapInit, heapInitFaults, heapTrav, heapTravFaults)
+	heap = nil
+
+	// mmap-backed slice
+	region, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		panic(err)
+	}
+	defer syscall.Munmap(region)
+
+	mapped := unsafe.Slice((*Point)(unsafe.Pointer(&region[0])), n)
+	mmapInit, mmapInitFaults := initialize(mapped)
+	mmapTrav, mmapTravFaults := traverse(mapped, iterations)
+	report("Anonymous mmap (unsafe.Slice over mapped region):", n, iterations, mmapInit, mmapInitFaults, mmapTrav, mmapTravFaults)
+
+	// Compare steady-state traversal
+	ratio := float64(mmapTrav) / float64(heapTrav)
+	fmt.Printf("Traversal ratio: %.2fx (mmap / heap)\n", ratio)
+	fmt.Println("\nNote: Large heap allocations also come fresh from the OS,")
+	fmt.Println("so both pay first-touch page faults. Transparent huge pages")
+	fmt.Println("can change fault counts for either region.")
+	fmt.Println("\nConclusion: Once resident, mmap'd memory traverses like any []Point.")
+	fmt.Println("The differences are in first touch and in what the GC has to manage.")
+	fmt.Println("Only pointer-free types belong there: the GC cannot see that memory.")
+}
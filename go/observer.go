@@ -0,0 +1,182 @@
+// Benchmark 19: Observer pattern, interface list vs callback slice
+// Run: go run observer.go
+//
+// Two common ways to fan an event out to subscribers:
+//   - []Observer: each subscriber implements Notify(Point), called via the itab
+//   - []func(Point): each subscriber registers a closure, called via its func value
+// Both are one indirect call per subscriber per event.
+// The question is whether either is measurably cheaper, so the choice
+// can be made on API grounds rather than performance folklore.
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Interface (dynamic dispatch per observer)
+type Observer interface {
+	Notify(p Point)
+}
+
+type Counter struct {
+	N int
+}
+
+func (c *Counter) Notify(p Point) {
+	c.N++
+}
+
+type Summer struct {
+	SumX, SumY int
+}
+
+func (s *Summer) Notify(p Point) {
+	s.SumX += p.X
+	s.SumY += p.Y
+}
+
+type Bounds struct {
+	MaxX, MaxY int
+}
+
+func (b *Bounds) Notify(p Point) {
+	if p.X > b.MaxX {
+		b.MaxX = p.X
+	}
+	if p.Y > b.MaxY {
+		b.MaxY = p.Y
+	}
+}
+
+type Last struct {
+	P Point
+}
+
+func (l *Last) Notify(p Point) {
+	l.P = p
+}
+
+// Global to prevent optimizer from eliminating notifications
+var gSum int
+
+// Benchmark notifying an []Observer list
+func benchmarkInterface(events, iterations int) time.Duration {
+	counter, summer, bounds, last := &Counter{}, &Summer{}, &Bounds{}, &Last{}
+	observers := []Observer{counter, summer, bounds, last}
+
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		for i := 0; i < events; i++ {
+			p := Point{X: i, Y: i >> 1}
+			for _, o := range observers {
+				o.Notify(p)  // Interface call
+			}
+		}
+	}
+
+	elapsed := time.Since(start)
+	gSum = counter.N + summer.SumX + bounds.MaxY + last.P.X
+	return elapsed
+}
+
+// Benchmark notifying a []func(Point) callback slice
+func benchmarkCallbacks(events, iterations int) time.Duration {
+	counter, summer, bounds, last := &Counter{}, &Summer{}, &Bounds{}, &Last{}
+	callbacks := []func(Point){
+		func(p Point) { counter.N++ },
+		func(p Point) {
+			summer.SumX += p.X
+			summer.SumY += p.Y
+		},
+		func(p Point) {
+			if p.X > bounds.MaxX {
+				bounds.MaxX = p.X
+			}
+			if p.Y > bounds.MaxY {
+				bounds.MaxY = p.Y
+			}
+		},
+		func(p Point) { last.P = p },
+	}
+
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		for i := 0; i < events; i++ {
+			p := Point{X: i, Y: i >> 1}
+			for _, cb := range callbacks {
+				cb(p)  // Closure call
+			}
+		}
+	}
+
+	elapsed := time.Since(start)
+	gSum = counter.N + summer.SumX + bounds.MaxY + last.P.X
+	return elapsed
+}
+
+func main() {
+	const events = 1000000  // 1 million notifications per iteration
+	const observers = 4
+	const iterations = 50  // Repeat the fan-out so each run takes hundreds of ms
+	const runs = 3  // Interleaved runs per design; the fastest is reported
+
+	fmt.Println("Benchmarking Go observer fan-out: []Observer vs []func(Point)")
+	fmt.Printf("Events: %d\n", events)
+	fmt.Printf("Observers per event: %d\n", observers)
+	fmt.Printf("Iterations: %d\n", iterations)
+	fmt.Printf("Total calls per run: %d\n", events*observers*iterations)
+	fmt.Printf("Runs: %d (best reported)\n\n", runs)
+
+	// Warm up
+	benchmarkInterface(1000, 10)
+	benchmarkCallbacks(1000, 10)
+
+	// Alternate designs so drift (frequency, other load) hits both equally
+	var interfaceTime, callbackTime time.Duration
+	for r := 0; r < runs; r++ {
+		if d := benchmarkInterface(events, iterations); r == 0 || d < interfaceTime {
+			interfaceTime = d
+		}
+		if d := benchmarkCallbacks(events, iterations); r == 0 || d < callbackTime {
+			callbackTime = d
+		}
+	}
+
+	total := float64(events * iterations)
+	interfacePerEvent := float64(interfaceTime.Nanoseconds()) / total
+	callbackPerEvent := float64(callbackTime.Nanoseconds()) / total
+
+	fmt.Println("Interface observers ([]Observer):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(interfaceTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per event: %.2f ns\n", interfacePerEvent)
+	fmt.Printf("  Time per call: %.2f ns\n\n", interfacePerEvent/observers)
+
+	fmt.Println("Callback slice ([]func(Point)):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(callbackTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per event: %.2f ns\n", callbackPerEvent)
+	fmt.Printf("  Time per call: %.2f ns\n\n", callbackPerEvent/observers)
+
+	// Compare
+	ratio := float64(interfaceTime) / float64(callbackTime)
+	fmt.Printf("Ratio: %.2fx (interface / callback)\n", ratio)
+	fmt.Println("\nConclusion: Both are one indirect call per observer.")
+	switch {
+	case ratio > 1.1:
+		fmt.Printf("Callbacks were %.2fx faster in this run; measure on your hardware\n", ratio)
+		fmt.Println("before letting that outweigh API shape.")
+	case ratio < 1/1.1:
+		fmt.Printf("Interfaces were %.2fx faster in this run; measure on your hardware\n", 1/ratio)
+		fmt.Println("before letting that outweigh API shape.")
+	default:
+		fmt.Println("They landed within 10% of each other: choose on API shape")
+		fmt.Println("(named types and multiple methods vs ad-hoc closures), not speed.")
+	}
+}
@@ -0,0 +1,361 @@
+// Benchmark 12: Partial struct reset, selective writes vs whole-struct zeroing
+// Run: go run partial_reset.go
+//
+// Object reuse (pools, free lists, entity recycling) needs a reset that keeps some state:
+// an ID, a generation counter, a cached buffer.
+// Two ways to do it:
+//   - Selective: write zero to each field that needs resetting
+//   - Whole: save the preserved fields, *e = Entity{} (one memclr), restore them
+// Each fraction gets its own straight-line reset functions (constant field indices),
+// the code a reset of named fields compiles to.
+// Reset fields are spread evenly, so every variant touches every cache line of the struct.
+// Whole-struct cost also depends on where the kept fields sit, not only their count.
+// whole60 keeps 4 fields on alternate cache lines (offsets 8, 136, 264, 392) and runs
+// ~1.3x slower than whole56 (8 kept) or whole63 (1 kept) on an Intel Xeon test machine.
+// Its code is the same clear loop with fewer loads and stores (no spills), and an identical
+// copy at another address is just as slow; the same 4 fields kept adjacent, or 64 bytes
+// apart, run at whole56 speed. So it is a memory-access-pattern effect, not codegen.
+
+package main
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+const numFields = 64
+
+type Entity struct {
+	Fields [numFields]int  // Stands in for 64 named 8-byte fields
+}
+
+// Global to prevent optimizer from eliminating resets
+var gSum int
+
+// Fastest of several runs (filters out scheduler and frequency noise)
+func bestOf(runs int, run func() time.Duration) time.Duration {
+	best := run()
+	for i := 1; i < runs; i++ {
+		if d := run(); d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+// Run one reset pass per round over the whole pool
+func benchmark(entities []Entity, rounds int, pass func([]Entity, int)) time.Duration {
+	start := time.Now()
+
+	for r := 0; r < rounds; r++ {
+		pass(entities, r)
+	}
+	gSum = entities[len(entities)-1].Fields[0]
+
+	return time.Since(start)
+}
+
+// Straight-line resets for each tested fraction, following splitFields.
+// Constant field indices compile to plain stores with no bounds checks,
+// as a reset of named fields would.
+//   - selectiveN: store zero into the N reset fields
+//   - wholeN: save the kept fields, clear the struct, restore them
+
+func selective8(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		f[0], f[8], f[16], f[24], f[32], f[40], f[48], f[56] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func whole8(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		k0, k1, k2, k3, k4, k5, k6, k7 := f[1], f[2], f[3], f[4], f[5], f[6], f[7], f[9]
+		k8, k9, k10, k11, k12, k13, k14, k15 := f[10], f[11], f[12], f[13], f[14], f[15], f[17], f[18]
+		k16, k17, k18, k19, k20, k21, k22, k23 := f[19], f[20], f[21], f[22], f[23], f[25], f[26], f[27]
+		k24, k25, k26, k27, k28, k29, k30, k31 := f[28], f[29], f[30], f[31], f[33], f[34], f[35], f[36]
+		k32, k33, k34, k35, k36, k37, k38, k39 := f[37], f[38], f[39], f[41], f[42], f[43], f[44], f[45]
+		k40, k41, k42, k43, k44, k45, k46, k47 := f[46], f[47], f[49], f[50], f[51], f[52], f[53], f[54]
+		k48, k49, k50, k51, k52, k53, k54, k55 := f[55], f[57], f[58], f[59], f[60], f[61], f[62], f[63]
+		entities[e] = Entity{}  // Single bulk clear
+		f[1], f[2], f[3], f[4], f[5], f[6], f[7], f[9] = k0, k1, k2, k3, k4, k5, k6, k7
+		f[10], f[11], f[12], f[13], f[14], f[15], f[17], f[18] = k8, k9, k10, k11, k12, k13, k14, k15
+		f[19], f[20], f[21], f[22], f[23], f[25], f[26], f[27] = k16, k17, k18, k19, k20, k21, k22, k23
+		f[28], f[29], f[30], f[31], f[33], f[34], f[35], f[36] = k24, k25, k26, k27, k28, k29, k30, k31
+		f[37], f[38], f[39], f[41], f[42], f[43], f[44], f[45] = k32, k33, k34, k35, k36, k37, k38, k39
+		f[46], f[47], f[49], f[50], f[51], f[52], f[53], f[54] = k40, k41, k42, k43, k44, k45, k46, k47
+		f[55], f[57], f[58], f[59], f[60], f[61], f[62], f[63] = k48, k49, k50, k51, k52, k53, k54, k55
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func selective16(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		f[0], f[4], f[8], f[12], f[16], f[20], f[24], f[28] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[32], f[36], f[40], f[44], f[48], f[52], f[56], f[60] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func whole16(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		k0, k1, k2, k3, k4, k5, k6, k7 := f[1], f[2], f[3], f[5], f[6], f[7], f[9], f[10]
+		k8, k9, k10, k11, k12, k13, k14, k15 := f[11], f[13], f[14], f[15], f[17], f[18], f[19], f[21]
+		k16, k17, k18, k19, k20, k21, k22, k23 := f[22], f[23], f[25], f[26], f[27], f[29], f[30], f[31]
+		k24, k25, k26, k27, k28, k29, k30, k31 := f[33], f[34], f[35], f[37], f[38], f[39], f[41], f[42]
+		k32, k33, k34, k35, k36, k37, k38, k39 := f[43], f[45], f[46], f[47], f[49], f[50], f[51], f[53]
+		k40, k41, k42, k43, k44, k45, k46, k47 := f[54], f[55], f[57], f[58], f[59], f[61], f[62], f[63]
+		entities[e] = Entity{}  // Single bulk clear
+		f[1], f[2], f[3], f[5], f[6], f[7], f[9], f[10] = k0, k1, k2, k3, k4, k5, k6, k7
+		f[11], f[13], f[14], f[15], f[17], f[18], f[19], f[21] = k8, k9, k10, k11, k12, k13, k14, k15
+		f[22], f[23], f[25], f[26], f[27], f[29], f[30], f[31] = k16, k17, k18, k19, k20, k21, k22, k23
+		f[33], f[34], f[35], f[37], f[38], f[39], f[41], f[42] = k24, k25, k26, k27, k28, k29, k30, k31
+		f[43], f[45], f[46], f[47], f[49], f[50], f[51], f[53] = k32, k33, k34, k35, k36, k37, k38, k39
+		f[54], f[55], f[57], f[58], f[59], f[61], f[62], f[63] = k40, k41, k42, k43, k44, k45, k46, k47
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func selective32(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		f[0], f[2], f[4], f[6], f[8], f[10], f[12], f[14] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[16], f[18], f[20], f[22], f[24], f[26], f[28], f[30] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[32], f[34], f[36], f[38], f[40], f[42], f[44], f[46] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[48], f[50], f[52], f[54], f[56], f[58], f[60], f[62] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func whole32(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		k0, k1, k2, k3, k4, k5, k6, k7 := f[1], f[3], f[5], f[7], f[9], f[11], f[13], f[15]
+		k8, k9, k10, k11, k12, k13, k14, k15 := f[17], f[19], f[21], f[23], f[25], f[27], f[29], f[31]
+		k16, k17, k18, k19, k20, k21, k22, k23 := f[33], f[35], f[37], f[39], f[41], f[43], f[45], f[47]
+		k24, k25, k26, k27, k28, k29, k30, k31 := f[49], f[51], f[53], f[55], f[57], f[59], f[61], f[63]
+		entities[e] = Entity{}  // Single bulk clear
+		f[1], f[3], f[5], f[7], f[9], f[11], f[13], f[15] = k0, k1, k2, k3, k4, k5, k6, k7
+		f[17], f[19], f[21], f[23], f[25], f[27], f[29], f[31] = k8, k9, k10, k11, k12, k13, k14, k15
+		f[33], f[35], f[37], f[39], f[41], f[43], f[45], f[47] = k16, k17, k18, k19, k20, k21, k22, k23
+		f[49], f[51], f[53], f[55], f[57], f[59], f[61], f[63] = k24, k25, k26, k27, k28, k29, k30, k31
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func selective48(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		f[0], f[2], f[3], f[4], f[6], f[7], f[8], f[10] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[11], f[12], f[14], f[15], f[16], f[18], f[19], f[20] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[22], f[23], f[24], f[26], f[27], f[28], f[30], f[31] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[32], f[34], f[35], f[36], f[38], f[39], f[40], f[42] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[43], f[44], f[46], f[47], f[48], f[50], f[51], f[52] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[54], f[55], f[56], f[58], f[59], f[60], f[62], f[63] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func whole48(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		k0, k1, k2, k3, k4, k5, k6, k7 := f[1], f[5], f[9], f[13], f[17], f[21], f[25], f[29]
+		k8, k9, k10, k11, k12, k13, k14, k15 := f[33], f[37], f[41], f[45], f[49], f[53], f[57], f[61]
+		entities[e] = Entity{}  // Single bulk clear
+		f[1], f[5], f[9], f[13], f[17], f[21], f[25], f[29] = k0, k1, k2, k3, k4, k5, k6, k7
+		f[33], f[37], f[41], f[45], f[49], f[53], f[57], f[61] = k8, k9, k10, k11, k12, k13, k14, k15
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func selective56(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		f[0], f[2], f[3], f[4], f[5], f[6], f[7], f[8] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[10], f[11], f[12], f[13], f[14], f[15], f[16], f[18] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[19], f[20], f[21], f[22], f[23], f[24], f[26], f[27] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[28], f[29], f[30], f[31], f[32], f[34], f[35], f[36] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[37], f[38], f[39], f[40], f[42], f[43], f[44], f[45] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[46], f[47], f[48], f[50], f[51], f[52], f[53], f[54] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[55], f[56], f[58], f[59], f[60], f[61], f[62], f[63] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func whole56(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		k0, k1, k2, k3, k4, k5, k6, k7 := f[1], f[9], f[17], f[25], f[33], f[41], f[49], f[57]
+		entities[e] = Entity{}  // Single bulk clear
+		f[1], f[9], f[17], f[25], f[33], f[41], f[49], f[57] = k0, k1, k2, k3, k4, k5, k6, k7
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func selective60(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		f[0], f[2], f[3], f[4], f[5], f[6], f[7], f[8] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[9], f[10], f[11], f[12], f[13], f[14], f[15], f[16] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[18], f[19], f[20], f[21], f[22], f[23], f[24], f[25] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[26], f[27], f[28], f[29], f[30], f[31], f[32], f[34] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[35], f[36], f[37], f[38], f[39], f[40], f[41], f[42] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[43], f[44], f[45], f[46], f[47], f[48], f[50], f[51] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[52], f[53], f[54], f[55], f[56], f[57], f[58], f[59] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[60], f[61], f[62], f[63] = 0, 0, 0, 0
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func whole60(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		k0, k1, k2, k3 := f[1], f[17], f[33], f[49]
+		entities[e] = Entity{}  // Single bulk clear
+		f[1], f[17], f[33], f[49] = k0, k1, k2, k3
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func selective63(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		f[0], f[2], f[3], f[4], f[5], f[6], f[7], f[8] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[9], f[10], f[11], f[12], f[13], f[14], f[15], f[16] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[17], f[18], f[19], f[20], f[21], f[22], f[23], f[24] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[25], f[26], f[27], f[28], f[29], f[30], f[31], f[32] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[33], f[34], f[35], f[36], f[37], f[38], f[39], f[40] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[41], f[42], f[43], f[44], f[45], f[46], f[47], f[48] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[49], f[50], f[51], f[52], f[53], f[54], f[55], f[56] = 0, 0, 0, 0, 0, 0, 0, 0
+		f[57], f[58], f[59], f[60], f[61], f[62], f[63] = 0, 0, 0, 0, 0, 0, 0
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+func whole63(entities []Entity, r int) {
+	for e := range entities {
+		f := &entities[e].Fields
+		k0 := f[1]
+		entities[e] = Entity{}  // Single bulk clear
+		f[1] = k0
+		f[0] = r  // Touch so rounds differ
+	}
+}
+
+// Split field indices into reset/keep, spreading kept fields across the struct
+func splitFields(resetCount int) (reset, keep []int) {
+	for i := 0; i < numFields; i++ {
+		// Field i is reset if it falls in the first resetCount slots of an even spread
+		if (i*resetCount)%numFields < resetCount {
+			reset = append(reset, i)
+		} else {
+			keep = append(keep, i)
+		}
+	}
+	return reset, keep
+}
+
+func main() {
+	const entities = 1000  // Pool size (fits in L2, so stores dominate)
+	const rounds = 2000
+
+	pool := make([]Entity, entities)
+	for i := range pool {
+		for f := range pool[i].Fields {
+			pool[i].Fields[f] = i + f
+		}
+	}
+
+	fmt.Println("Benchmarking Go partial reset: selective fields vs whole-struct zeroing")
+	fmt.Printf("Entities: %d\n", entities)
+	fmt.Printf("Rounds: %d\n", rounds)
+	fmt.Printf("Entity size: %d bytes (%d fields)\n\n", int(unsafe.Sizeof(Entity{})), numFields)
+
+	variants := []struct {
+		resetCount       int
+		selective, whole func([]Entity, int)
+	}{
+		{8, selective8, whole8},
+		{16, selective16, whole16},
+		{32, selective32, whole32},
+		{48, selective48, whole48},
+		{56, selective56, whole56},
+		{60, selective60, whole60},
+		{63, selective63, whole63},
+	}
+
+	// Both resets of a pair must match splitFields and each other
+	for _, v := range variants {
+		reset, keep := splitFields(v.resetCount)
+		a := append([]Entity(nil), pool[:1]...)
+		b := append([]Entity(nil), pool[:1]...)
+		v.selective(a, 0)
+		v.whole(b, 0)
+		for _, f := range reset {
+			if a[0].Fields[f] != 0 {
+				panic("selective reset missed a field")
+			}
+		}
+		for _, f := range keep {
+			if a[0].Fields[f] != pool[0].Fields[f] {
+				panic("selective reset cleared a kept field")
+			}
+		}
+		if a[0] != b[0] {
+			panic("selective and whole resets disagree")
+		}
+	}
+
+	// Warm up
+	benchmark(pool[:100], 10, selective32)
+	benchmark(pool[:100], 10, whole32)
+
+	fmt.Println("Time per reset (ns) by fraction of fields reset:")
+	fmt.Printf("  %-8s %6s %6s  %10s %10s  %s\n", "Reset", "Reset", "Kept", "Selective", "Whole", "Faster")
+
+	firstWhole := -1.0  // Lowest reset fraction where whole clearly wins
+	for _, v := range variants {
+		kept := numFields - v.resetCount
+		frac := 100 * float64(v.resetCount) / numFields
+
+		sel := bestOf(3, func() time.Duration { return benchmark(pool, rounds, v.selective) })
+		whole := bestOf(3, func() time.Duration { return benchmark(pool, rounds, v.whole) })
+
+		selPer := float64(sel.Nanoseconds()) / float64(entities*rounds)
+		wholePer := float64(whole.Nanoseconds()) / float64(entities*rounds)
+
+		faster := "selective"
+		ratio := wholePer / selPer
+		if wholePer < selPer {
+			faster = "whole"
+			ratio = selPer / wholePer
+		}
+		if ratio < 1.1 {
+			faster = "tie"  // Within run-to-run noise
+		}
+		if faster == "whole" && firstWhole < 0 {
+			firstWhole = frac
+		}
+
+		fmt.Printf("  %6.1f%%  %6d %6d  %10.2f %10.2f  %s (%.2fx)\n",
+			frac, v.resetCount, kept, selPer, wholePer, faster, ratio)
+	}
+
+	fmt.Println("\nNote: Best of 3 runs per cell; differences within 1.1x are reported as ties.")
+	fmt.Println("Whole-struct time depends on which cache lines the kept fields sit on,")
+	fmt.Println("not only how many there are (see the file header).")
+	fmt.Println("\nConclusion: Selective cost grows far more slowly than the store count:")
+	fmt.Println("every variant touches every cache line, and that sets most of the price.")
+	if firstWhole < 0 {
+		fmt.Println("Whole-struct zeroing never clearly won in this run. Zero the whole struct")
+		fmt.Println("for simplicity (no field can be forgotten), not for speed.")
+	} else {
+		fmt.Printf("Whole-struct zeroing first clearly won at %.1f%% reset.\n", firstWhole)
+		fmt.Println("Below that, reset the fields you need and skip the save/restore.")
+	}
+}
@@ -0,0 +1,138 @@
+// Benchmark 26: Reductions with one accumulator vs several independent ones
+// Run: go run pipelining.go
+//
+// sum += p.X + p.Y in a loop is a loop-carried dependency chain:
+// each add has to wait for the previous one to finish.
+// Modern CPUs can issue several adds per cycle, but one chain uses only one at a time.
+// Splitting the sum into independent accumulators (s0, s1, s2, s3)
+// gives the CPU four chains to overlap, then combines them at the end.
+//   - float64 adds have ~3-4 cycles latency: breaking the chain helps most
+//   - int adds have ~1 cycle latency: the gain is smaller, much of it from unrolling
+// The data is sized to stay in cache so the ALUs, not memory, are the limit.
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type Point struct {
+	X, Y float64
+}
+
+type IntPoint struct {
+	X, Y int
+}
+
+// Single float accumulator (one dependency chain)
+func sumFloat1(points []Point) float64 {
+	s := 0.0
+	for i := range points {
+		s += points[i].X + points[i].Y
+	}
+	return s
+}
+
+// Four float accumulators (four independent chains)
+func sumFloat4(points []Point) float64 {
+	var s0, s1, s2, s3 float64
+	i := 0
+	for ; i+4 <= len(points); i += 4 {
+		q := points[i : i+4 : i+4]  // One bounds check per group of four
+		s0 += q[0].X + q[0].Y
+		s1 += q[1].X + q[1].Y
+		s2 += q[2].X + q[2].Y
+		s3 += q[3].X + q[3].Y
+	}
+	for ; i < len(points); i++ {
+		s0 += points[i].X + points[i].Y
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+// Single int accumulator
+func sumInt1(points []IntPoint) int {
+	s := 0
+	for i := range points {
+		s += points[i].X + points[i].Y
+	}
+	return s
+}
+
+// Four int accumulators
+func sumInt4(points []IntPoint) int {
+	var s0, s1, s2, s3 int
+	i := 0
+	for ; i+4 <= len(points); i += 4 {
+		q := points[i : i+4 : i+4]  // One bounds check per group of four
+		s0 += q[0].X + q[0].Y
+		s1 += q[1].X + q[1].Y
+		s2 += q[2].X + q[2].Y
+		s3 += q[3].X + q[3].Y
+	}
+	for ; i < len(points); i++ {
+		s0 += points[i].X + points[i].Y
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+func benchmark[T any, R comparable](points []T, iterations int, sum func([]T) R) (time.Duration, R) {
+	var r R
+	start := time.Now()
+	for iter := 0; iter < iterations; iter++ {
+		r = sum(points)
+	}
+	return time.Since(start), r
+}
+
+func main() {
+	const n = 4096  // 64 KB of float points: stays in L1/L2
+	const iterations = 50000
+
+	points := make([]Point, n)
+	intPoints := make([]IntPoint, n)
+	for i := 0; i < n; i++ {
+		points[i] = Point{X: float64(i), Y: float64(i)}  // Integer-valued: sums are exact
+		intPoints[i] = IntPoint{X: i, Y: i}
+	}
+
+	fmt.Println("Benchmarking Go reductions: single vs multiple accumulators")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Iterations: %d\n\n", iterations)
+
+	// Warm up
+	benchmark(points, 100, sumFloat1)
+	benchmark(points, 100, sumFloat4)
+
+	f1Time, f1 := benchmark(points, iterations, sumFloat1)
+	f4Time, f4 := benchmark(points, iterations, sumFloat4)
+	i1Time, i1 := benchmark(intPoints, iterations, sumInt1)
+	i4Time, i4 := benchmark(intPoints, iterations, sumInt4)
+
+	// Reassociation must not change the result
+	if f1 != f4 || i1 != i4 {
+		panic("sums differ")
+	}
+
+	perElement := func(d time.Duration) float64 {
+		return float64(d.Nanoseconds()) / float64(n*iterations)
+	}
+
+	fmt.Println("float64 ([]Point{X, Y float64}):")
+	fmt.Printf("  1 accumulator:  %.3f ns/element\n", perElement(f1Time))
+	fmt.Printf("  4 accumulators: %.3f ns/element\n", perElement(f4Time))
+	fmt.Printf("  Speedup: %.2fx\n\n", float64(f1Time)/float64(f4Time))
+
+	fmt.Println("int ([]IntPoint{X, Y int}):")
+	fmt.Printf("  1 accumulator:  %.3f ns/element\n", perElement(i1Time))
+	fmt.Printf("  4 accumulators: %.3f ns/element\n", perElement(i4Time))
+	fmt.Printf("  Speedup: %.2fx\n\n", float64(i1Time)/float64(i4Time))
+
+	fmt.Println("Note: Go does not reassociate float adds for you (it would change rounding),")
+	fmt.Println("so the single-accumulator float loop stays one serial chain.")
+	fmt.Println("For non-integer data, multiple accumulators can change the last bits of the result.")
+	fmt.Println("\nConclusion: Independent accumulators turn one long dependency chain")
+	fmt.Println("into several short ones the CPU can run in parallel.")
+	fmt.Println("Contiguous value slices make this easy: the data is already in order.")
+}
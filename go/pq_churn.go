@@ -0,0 +1,277 @@
+// Benchmark 25: Priority queue under churn, container/heap vs generic vs concrete heap
+// Run: go run pq_churn.go
+//
+// container/heap drives any type through heap.Interface:
+//   - Less and Swap are interface calls, O(log n) of them per push/pop
+//   - Push and Pop take/return `any`, boxing the Point in both directions
+// A generic Heap[Point, byXY] moves Points directly with no boxing, but Go compiles
+// generics per GC shape: h.ord.Less is still an indirect call (through the
+// dictionary) on every sift step.
+// A concrete heap over []Point calls lessPoint directly, so it is inlined.
+// Under high churn (pop the min, push a new item) that overhead compounds.
+
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+func lessPoint(a, b Point) bool {
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	return a.Y < b.Y
+}
+
+// container/heap adapter (interface dispatch per sift step)
+type PointHeap []Point
+
+func (h PointHeap) Len() int           { return len(h) }
+func (h PointHeap) Less(i, j int) bool { return lessPoint(h[i], h[j]) }
+func (h PointHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *PointHeap) Push(x any)        { *h = append(*h, x.(Point)) }
+func (h *PointHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// Ordering is implemented by a zero-size comparator type
+type Ordering[T any] interface {
+	Less(a, b T) bool
+}
+
+type byXY struct{}
+
+func (byXY) Less(a, b Point) bool { return lessPoint(a, b) }
+
+// Generic binary min-heap (no boxing; Less is called through the shape dictionary)
+type Heap[T any, O Ordering[T]] struct {
+	data []T
+	ord  O
+}
+
+func (h *Heap[T, O]) Len() int { return len(h.data) }
+
+func (h *Heap[T, O]) Push(x T) {
+	h.data = append(h.data, x)
+	i := len(h.data) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.ord.Less(h.data[i], h.data[parent]) {
+			break
+		}
+		h.data[i], h.data[parent] = h.data[parent], h.data[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T, O]) Pop() T {
+	n := len(h.data) - 1
+	top := h.data[0]
+	h.data[0] = h.data[n]
+	h.data = h.data[:n]
+	i := 0
+	for {
+		left := 2*i + 1
+		if left >= n {
+			break
+		}
+		child := left
+		if right := left + 1; right < n && h.ord.Less(h.data[right], h.data[left]) {
+			child = right
+		}
+		if !h.ord.Less(h.data[child], h.data[i]) {
+			break
+		}
+		h.data[i], h.data[child] = h.data[child], h.data[i]
+		i = child
+	}
+	return top
+}
+
+// Concrete binary min-heap over []Point (lessPoint inlined into the sift loops)
+type PointMinHeap struct {
+	data []Point
+}
+
+func (h *PointMinHeap) Push(x Point) {
+	h.data = append(h.data, x)
+	i := len(h.data) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !lessPoint(h.data[i], h.data[parent]) {
+			break
+		}
+		h.data[i], h.data[parent] = h.data[parent], h.data[i]
+		i = parent
+	}
+}
+
+func (h *PointMinHeap) Pop() Point {
+	n := len(h.data) - 1
+	top := h.data[0]
+	h.data[0] = h.data[n]
+	h.data = h.data[:n]
+	i := 0
+	for {
+		left := 2*i + 1
+		if left >= n {
+			break
+		}
+		child := left
+		if right := left + 1; right < n && lessPoint(h.data[right], h.data[left]) {
+			child = right
+		}
+		if !lessPoint(h.data[child], h.data[i]) {
+			break
+		}
+		h.data[i], h.data[child] = h.data[child], h.data[i]
+		i = child
+	}
+	return top
+}
+
+// Global to prevent optimizer from eliminating operations
+var gSum int
+
+// Order-sensitive hash of the popped sequence (cross-checks the heaps)
+func mix(h int, p Point) int {
+	return h*31 + p.X<<20 + p.Y
+}
+
+func makeItems(n int, seed int64) []Point {
+	r := rand.New(rand.NewSource(seed))
+	items := make([]Point, n)
+	for i := range items {
+		items[i] = Point{X: r.Intn(1 << 20), Y: r.Intn(1 << 20)}
+	}
+	return items
+}
+
+// Pop the min and push a fresh item, repeatedly
+func benchmarkContainerHeap(initial, pushes []Point) (time.Duration, uint64, int) {
+	h := make(PointHeap, len(initial))
+	copy(h, initial)
+	heap.Init(&h)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	sum := 0
+	for _, p := range pushes {
+		sum = mix(sum, heap.Pop(&h).(Point))
+		heap.Push(&h, p)  // Boxes p into any
+	}
+	gSum = sum
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, sum
+}
+
+func benchmarkGenericHeap(initial, pushes []Point) (time.Duration, uint64, int) {
+	h := &Heap[Point, byXY]{data: make([]Point, 0, len(initial))}
+	for _, p := range initial {
+		h.Push(p)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	sum := 0
+	for _, p := range pushes {
+		sum = mix(sum, h.Pop())
+		h.Push(p)
+	}
+	gSum = sum
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, sum
+}
+
+func benchmarkConcreteHeap(initial, pushes []Point) (time.Duration, uint64, int) {
+	h := &PointMinHeap{data: make([]Point, 0, len(initial))}
+	for _, p := range initial {
+		h.Push(p)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	sum := 0
+	for _, p := range pushes {
+		sum = mix(sum, h.Pop())
+		h.Push(p)
+	}
+	gSum = sum
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, sum
+}
+
+func main() {
+	const size = 100000  // Items kept in the queue
+	const ops = 2000000  // Pop+push pairs
+
+	initial := makeItems(size, 1)
+	pushes := makeItems(ops, 2)
+
+	fmt.Println("Benchmarking Go priority queue churn: container/heap vs generic vs concrete heap")
+	fmt.Printf("Queue size: %d\n", size)
+	fmt.Printf("Pop+push pairs: %d\n\n", ops)
+
+	// Warm up
+	benchmarkContainerHeap(initial[:1000], pushes[:1000])
+	benchmarkGenericHeap(initial[:1000], pushes[:1000])
+	benchmarkConcreteHeap(initial[:1000], pushes[:1000])
+
+	report := func(name string, elapsed time.Duration, allocs uint64) {
+		fmt.Println(name)
+		fmt.Printf("  Total time: %.2f ms\n", float64(elapsed.Microseconds())/1000.0)
+		fmt.Printf("  Throughput: %.2f M ops/s\n", float64(2*ops)/elapsed.Seconds()/1e6)
+		fmt.Printf("  Allocs per pop+push: %.2f\n\n", float64(allocs)/float64(ops))
+	}
+
+	containerTime, containerAllocs, containerSeq := benchmarkContainerHeap(initial, pushes)
+	report("container/heap (heap.Interface + any):", containerTime, containerAllocs)
+
+	genericTime, genericAllocs, genericSeq := benchmarkGenericHeap(initial, pushes)
+	report("Generic Heap[Point, byXY] (Less via shape dictionary):", genericTime, genericAllocs)
+
+	concreteTime, concreteAllocs, concreteSeq := benchmarkConcreteHeap(initial, pushes)
+	report("Concrete PointMinHeap (lessPoint inlined):", concreteTime, concreteAllocs)
+
+	// All three heaps must pop the same sequence
+	if containerSeq != genericSeq || containerSeq != concreteSeq {
+		panic("heaps popped different sequences")
+	}
+
+	// Calculate speedups
+	fmt.Printf("Speedup: %.2fx faster for generic heap vs container/heap (no boxing, no Swap/Len dispatch)\n",
+		float64(containerTime)/float64(genericTime))
+	fmt.Printf("Speedup: %.2fx faster for concrete heap vs generic heap (no Less dispatch)\n",
+		float64(genericTime)/float64(concreteTime))
+	fmt.Printf("Speedup: %.2fx faster for concrete heap vs container/heap\n",
+		float64(containerTime)/float64(concreteTime))
+	fmt.Println("\nConclusion: container/heap pays an interface call per comparison and swap,")
+	fmt.Println("~log2(n) of each per operation, plus boxing on every push and pop.")
+	fmt.Println("A generic heap removes the boxing and the Swap dispatch, but its comparator")
+	fmt.Println("is still an indirect call per sift step. Only a heap written for []Point")
+	fmt.Println("removes comparison dispatch entirely.")
+}
@@ -0,0 +1,125 @@
+// Benchmark 14: Formatting struct slices, fmt.Fprintf vs strconv.AppendInt
+// Run: go run printf_slice.go
+//
+// Writing a []Point as CSV with fmt.Fprintf(w, "%d,%d\n", p.X, p.Y) looks free,
+// but every argument is converted to `any`:
+//   - ints outside the small-value cache are boxed (heap allocated)
+//   - the format string is parsed on every call
+//   - each value goes through reflection-style type switching
+// A manual writer appends digits straight into a reused []byte with strconv.AppendInt.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Format each row with fmt.Fprintf (boxes X and Y into any)
+func benchmarkFprintf(points []Point, w io.Writer) (time.Duration, uint64, int) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriterSize(cw, 64*1024)
+
+	start := time.Now()
+	for _, p := range points {
+		fmt.Fprintf(bw, "%d,%d\n", p.X, p.Y)  // Boxing + format parsing per row
+	}
+	bw.Flush()
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, cw.n
+}
+
+// Format each row with strconv.AppendInt into a reused buffer (no boxing)
+func benchmarkAppendInt(points []Point, w io.Writer) (time.Duration, uint64, int) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	cw := &countingWriter{w: w}
+	buf := make([]byte, 0, 64*1024)
+
+	start := time.Now()
+	for _, p := range points {
+		buf = strconv.AppendInt(buf, int64(p.X), 10)
+		buf = append(buf, ',')
+		buf = strconv.AppendInt(buf, int64(p.Y), 10)
+		buf = append(buf, '\n')
+		if len(buf) > cap(buf)-64 {
+			cw.Write(buf)
+			buf = buf[:0]
+		}
+	}
+	cw.Write(buf)
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, cw.n
+}
+
+// Counts bytes so both writers can be checked for identical output size
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	c.n += len(b)
+	return c.w.Write(b)
+}
+
+func main() {
+	const n = 1000000  // 1 million rows
+
+	points := make([]Point, n)
+	for i := 0; i < n; i++ {
+		points[i] = Point{X: i * 1000, Y: -i}  // Mostly outside the small-int cache
+	}
+
+	fmt.Println("Benchmarking Go CSV formatting: fmt.Fprintf vs strconv.AppendInt")
+	fmt.Printf("Rows: %d\n\n", n)
+
+	// Warm up
+	benchmarkFprintf(points[:1000], io.Discard)
+	benchmarkAppendInt(points[:1000], io.Discard)
+
+	// Benchmark fmt.Fprintf
+	fmtTime, fmtAllocs, fmtBytes := benchmarkFprintf(points, io.Discard)
+
+	fmt.Println("fmt.Fprintf per row (X and Y boxed into any):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(fmtTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per row: %d ns\n", fmtTime.Nanoseconds()/int64(n))
+	fmt.Printf("  Allocs per row: %.2f\n", float64(fmtAllocs)/float64(n))
+	fmt.Printf("  Throughput: %.1f MB/s\n\n", float64(fmtBytes)/(1<<20)/fmtTime.Seconds())
+
+	// Benchmark strconv.AppendInt
+	appTime, appAllocs, appBytes := benchmarkAppendInt(points, io.Discard)
+
+	fmt.Println("strconv.AppendInt into a reused []byte:")
+	fmt.Printf("  Total time: %.2f ms\n", float64(appTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per row: %d ns\n", appTime.Nanoseconds()/int64(n))
+	fmt.Printf("  Allocs per row: %.2f\n", float64(appAllocs)/float64(n))
+	fmt.Printf("  Throughput: %.1f MB/s\n\n", float64(appBytes)/(1<<20)/appTime.Seconds())
+
+	if fmtBytes != appBytes {
+		panic("output size mismatch")
+	}
+
+	// Calculate speedup
+	speedup := float64(fmtTime) / float64(appTime)
+	fmt.Printf("Output: %.1f MB (identical for both)\n", float64(appBytes)/(1<<20))
+	fmt.Printf("Speedup: %.2fx faster for strconv.AppendInt\n", speedup)
+	fmt.Println("\nConclusion: Printf-style formatting boxes every argument into any.")
+	fmt.Println("For row-oriented output over struct slices, append digits directly.")
+}
@@ -0,0 +1,103 @@
+// Benchmark 10: Shrinking large slices and the memory they retain
+// Run: go run resize.go
+//
+// Reslicing a large []Point down to a few elements is O(1),
+// but the small slice still points into the large backing array,
+// so the GC cannot free any of it. Three ways to shrink:
+//   - s[:n]                keeps len n, keeps full capacity, keeps all memory
+//   - slices.Clip(s[:n])   drops capacity to n, but is still a reslice: keeps all memory
+//   - copy to a new slice  allocates n elements, lets the old array be freed
+// Only the copy releases memory. Clip only stops append from writing into the tail.
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Global so the shrunken slice stays live across GC
+var gKeep []Point
+
+func heapAlloc() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+func makeLarge(n int) []Point {
+	s := make([]Point, n)
+	for i := range s {
+		s[i] = Point{X: i, Y: i}
+	}
+	return s
+}
+
+// Shrink the large slice with the given strategy, then measure
+// how much heap is freed once only the result is reachable
+func benchmarkShrink(large int, keep int, shrink func([]Point, int) []Point) (time.Duration, int64, int) {
+	gKeep = nil
+
+	s := makeLarge(large)
+	withLarge := heapAlloc()
+
+	start := time.Now()
+	gKeep = shrink(s, keep)
+	elapsed := time.Since(start)
+
+	s = nil
+	after := heapAlloc()
+
+	freed := int64(withLarge) - int64(after)
+	if freed < 0 {
+		freed = 0  // Small heap noise when nothing is freed
+	}
+	return elapsed, freed, cap(gKeep)
+}
+
+func main() {
+	const large = 10000000  // 10 million points (~160 MB)
+	const keep = 1000
+
+	fmt.Println("Benchmarking Go slice shrinking: reslice vs Clip vs copy")
+	fmt.Printf("Large slice: %d elements (%.1f MB)\n", large, float64(large*16)/(1<<20))
+	fmt.Printf("Kept elements: %d\n\n", keep)
+
+	strategies := []struct {
+		name   string
+		shrink func([]Point, int) []Point
+	}{
+		{"s = s[:n]", func(s []Point, n int) []Point {
+			return s[:n]
+		}},
+		{"s = slices.Clip(s[:n])", func(s []Point, n int) []Point {
+			return slices.Clip(s[:n])
+		}},
+		{"s = append([]Point(nil), s[:n]...)", func(s []Point, n int) []Point {
+			return append([]Point(nil), s[:n]...)
+		}},
+	}
+
+	for _, st := range strategies {
+		elapsed, freed, capacity := benchmarkShrink(large, keep, st.shrink)
+		fmt.Printf("%s:\n", st.name)
+		fmt.Printf("  Shrink time: %d ns\n", elapsed.Nanoseconds())
+		fmt.Printf("  Resulting cap: %d\n", capacity)
+		fmt.Printf("  Memory freed: %.1f MB\n\n", float64(freed)/(1<<20))
+	}
+
+	fmt.Println("Tradeoff:")
+	fmt.Println("  s[:n]         O(1), retains the whole array, append reuses the tail")
+	fmt.Println("  slices.Clip   O(1), retains the whole array, next append reallocates")
+	fmt.Println("  copy          O(n) copy of kept elements, frees the whole array")
+	fmt.Println("\nConclusion: A small slice of a large array pins the large array.")
+	fmt.Println("For long-lived results, copy what you keep (slices.Clone works too).")
+	fmt.Println("slices.Clip limits capacity; it does not release memory.")
+}
@@ -0,0 +1,154 @@
+// Benchmark 24: Reusable scratch buffer in a struct field vs per-call allocation
+// Run: go run scratch_field.go
+//
+// Many processing steps need temporary working space:
+// filter a batch into a temporary slice, then make a second pass over it.
+// A stateless function allocates that space on every call.
+// A processor type can own the scratch slice as a field and reuse it:
+//   p.scratch = p.scratch[:0]   // keep capacity, drop contents
+// After the first call, the buffer has grown to fit and allocations stop.
+// (Not safe for concurrent use: one processor per goroutine.)
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Spread in X of the points inside the box, allocating scratch per call
+func spreadInBoxAlloc(points []Point, maxX, maxY int) int {
+	scratch := make([]Point, 0, len(points))  // Fresh allocation every call
+	for _, p := range points {
+		if p.X <= maxX && p.Y <= maxY {
+			scratch = append(scratch, p)
+		}
+	}
+	return spread(scratch)
+}
+
+// Processor owns its scratch space and reuses it across calls
+type Processor struct {
+	scratch []Point
+}
+
+func (pr *Processor) SpreadInBox(points []Point, maxX, maxY int) int {
+	pr.scratch = pr.scratch[:0]  // Reuse capacity from earlier calls
+	for _, p := range points {
+		if p.X <= maxX && p.Y <= maxY {
+			pr.scratch = append(pr.scratch, p)
+		}
+	}
+	return spread(pr.scratch)
+}
+
+// Second pass over the filtered points: sum of |X - mean X|
+func spread(points []Point) int {
+	if len(points) == 0 {
+		return 0
+	}
+	mean := 0
+	for _, p := range points {
+		mean += p.X
+	}
+	mean /= len(points)
+	total := 0
+	for _, p := range points {
+		d := p.X - mean
+		if d < 0 {
+			d = -d
+		}
+		total += d
+	}
+	return total
+}
+
+// Global to prevent optimizer from eliminating calls
+var gSum int
+
+func makeBatch(n int, seed int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		v := (i*7919 + seed*104729) % 1000
+		points[i] = Point{X: v, Y: (v * 31) % 1000}
+	}
+	return points
+}
+
+func benchmarkAlloc(batches [][]Point, calls int) (time.Duration, uint64, uint64) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	sum := 0
+	for c := 0; c < calls; c++ {
+		sum += spreadInBoxAlloc(batches[c%len(batches)], 800, 800)
+	}
+	gSum = sum
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc
+}
+
+func benchmarkReuse(batches [][]Point, calls int) (time.Duration, uint64, uint64) {
+	var before, after runtime.MemStats
+	pr := &Processor{}
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	sum := 0
+	for c := 0; c < calls; c++ {
+		sum += pr.SpreadInBox(batches[c%len(batches)], 800, 800)
+	}
+	gSum = sum
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc
+}
+
+func report(name string, calls int, elapsed time.Duration, allocs, bytes uint64) {
+	fmt.Println(name)
+	fmt.Printf("  Total time: %.2f ms\n", float64(elapsed.Microseconds())/1000.0)
+	fmt.Printf("  Time per call: %.2f us\n", float64(elapsed.Nanoseconds())/float64(calls)/1000.0)
+	fmt.Printf("  Allocs per call: %.3f\n", float64(allocs)/float64(calls))
+	fmt.Printf("  Bytes per call: %.0f\n\n", float64(bytes)/float64(calls))
+}
+
+func main() {
+	const batchSize = 1000  // Points per call
+	const calls = 500000
+
+	batches := make([][]Point, 16)
+	for i := range batches {
+		batches[i] = makeBatch(batchSize, i)
+	}
+
+	fmt.Println("Benchmarking Go scratch space: per-call allocation vs reusable field")
+	fmt.Printf("Points per call: %d\n", batchSize)
+	fmt.Printf("Calls: %d\n\n", calls)
+
+	// Warm up
+	benchmarkAlloc(batches, 1000)
+	benchmarkReuse(batches, 1000)
+
+	allocTime, allocAllocs, allocBytes := benchmarkAlloc(batches, calls)
+	report("Per-call scratch (make in every call):", calls, allocTime, allocAllocs, allocBytes)
+
+	reuseTime, reuseAllocs, reuseBytes := benchmarkReuse(batches, calls)
+	report("Reusable scratch field (Processor.scratch):", calls, reuseTime, reuseAllocs, reuseBytes)
+
+	// Calculate savings
+	speedup := float64(allocTime) / float64(reuseTime)
+	fmt.Printf("Saved: %.1f MB allocated over %d calls\n", float64(allocBytes-reuseBytes)/(1<<20), calls)
+	fmt.Printf("Speedup: %.2fx faster for reusable scratch\n", speedup)
+	fmt.Println("\nConclusion: Owning scratch space in a struct field amortizes its")
+	fmt.Println("allocation to roughly zero. The price is state: a Processor")
+	fmt.Println("must not be shared between goroutines without synchronization.")
+}
@@ -0,0 +1,132 @@
+// Benchmark 8: Goroutine stack growth with value-heavy call chains
+// Run: go run stack_copy.go
+//
+// Goroutines start with a small stack (a few KB) that grows on demand.
+// Growing means allocating a stack twice the size and COPYING the old one.
+// A deep call chain where every frame holds a large struct by value:
+//   - makes each frame big, so the stack grows (and is copied) many times
+// The same chain passing *Point keeps frames small and rarely grows.
+// The effect is paid per goroutine, so it shows up with many short-lived ones.
+
+package main
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+type Point struct {
+	X, Y int
+	Data [64]int  // Large enough to make frames expensive
+}
+
+// Global to prevent optimizer from eliminating calls
+var gSum int
+
+// Each frame holds its own copy of Point (argument + outgoing argument)
+//
+//go:noinline
+func recurseValue(p Point, depth int) int {
+	if depth == 0 {
+		return p.X
+	}
+	p.X++
+	return recurseValue(p, depth-1) + p.Data[depth%len(p.Data)]
+}
+
+// Each frame holds only a pointer
+//
+//go:noinline
+func recursePointer(p *Point, depth int) int {
+	if depth == 0 {
+		return p.X
+	}
+	p.X++
+	return recursePointer(p, depth-1) + p.Data[depth%len(p.Data)]
+}
+
+// Fresh goroutine per run: the stack starts small and must grow every time
+func benchmarkFresh(runs int, depth int, call func(int) int) time.Duration {
+	done := make(chan int)
+
+	start := time.Now()
+
+	for r := 0; r < runs; r++ {
+		go func() {
+			done <- call(depth)
+		}()
+		gSum += <-done
+	}
+
+	return time.Since(start)
+}
+
+// Same goroutine, repeated runs: the stack has already grown after the first
+func benchmarkWarm(runs int, depth int, call func(int) int) time.Duration {
+	done := make(chan time.Duration)
+
+	go func() {
+		gSum += call(depth)  // Grow the stack once, untimed
+
+		start := time.Now()
+		for r := 0; r < runs; r++ {
+			gSum += call(depth)
+		}
+		done <- time.Since(start)
+	}()
+
+	return <-done
+}
+
+func main() {
+	const depth = 1000  // Call chain depth
+	const runs = 5000
+
+	value := func(d int) int {
+		return recurseValue(Point{X: 1, Y: 2}, d)
+	}
+	pointer := func(d int) int {
+		p := Point{X: 1, Y: 2}
+		return recursePointer(&p, d)
+	}
+
+	fmt.Println("Benchmarking Go stack growth: value frames vs pointer frames")
+	fmt.Printf("Call depth: %d\n", depth)
+	fmt.Printf("Runs: %d\n", runs)
+	fmt.Printf("Point size: %d bytes\n\n", int(unsafe.Sizeof(Point{})))
+
+	// Warm up
+	benchmarkFresh(100, depth, value)
+	benchmarkFresh(100, depth, pointer)
+
+	valueFresh := benchmarkFresh(runs, depth, value)
+	valueWarm := benchmarkWarm(runs, depth, value)
+	pointerFresh := benchmarkFresh(runs, depth, pointer)
+	pointerWarm := benchmarkWarm(runs, depth, pointer)
+
+	perRun := func(d time.Duration) float64 {
+		return float64(d.Nanoseconds()) / float64(runs) / 1000.0
+	}
+
+	fmt.Println("Value frames (Point passed by value):")
+	fmt.Printf("  Fresh goroutine: %.2f us per run\n", perRun(valueFresh))
+	fmt.Printf("  Grown stack:     %.2f us per run\n", perRun(valueWarm))
+	fmt.Printf("  Growth + copy:   %.2f us per run\n\n", perRun(valueFresh)-perRun(valueWarm))
+
+	fmt.Println("Pointer frames (*Point passed down):")
+	fmt.Printf("  Fresh goroutine: %.2f us per run\n", perRun(pointerFresh))
+	fmt.Printf("  Grown stack:     %.2f us per run\n", perRun(pointerWarm))
+	fmt.Printf("  Growth + copy:   %.2f us per run\n\n", perRun(pointerFresh)-perRun(pointerWarm))
+
+	// Calculate difference
+	speedup := float64(valueFresh) / float64(pointerFresh)
+	fmt.Printf("Speedup: %.2fx faster for pointer frames in fresh goroutines\n", speedup)
+	fmt.Println("\nNote: \"Grown stack\" still includes copying Point into each frame.")
+	fmt.Println("\"Growth + copy\" is the extra cost of growing the goroutine stack.")
+	fmt.Println("Each growth step also walks every live frame to adjust pointers,")
+	fmt.Println("so deep chains pay some growth cost even with small frames.")
+	fmt.Println("\nConclusion: Large values in deep call chains inflate frame size,")
+	fmt.Println("so each new goroutine grows (and copies) its stack more times.")
+	fmt.Println("This is rare in practice, but real for deep recursion over big values.")
+}
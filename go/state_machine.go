@@ -0,0 +1,181 @@
+// Benchmark 23: State machine, State interface vs integer state + switch
+// Run: go run state_machine.go
+//
+// The OO "State pattern" makes each state a type and each transition a method call:
+//   state = state.Next(c, m)
+// Every input byte pays an interface call, and the compiler can't inline
+// across states or keep the machine in registers.
+// The switch-based version is an integer and a switch in one loop:
+// the whole machine is visible to the compiler at once.
+// Both machines tokenize the same input into words and numbers.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Counters shared by both machines
+type Machine struct {
+	Words, Numbers int
+}
+
+// Interface design (one type per state)
+type State interface {
+	Next(c byte, m *Machine) State
+}
+
+type Idle struct{}
+type InWord struct{}
+type InNumber struct{}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+func (Idle) Next(c byte, m *Machine) State {
+	switch {
+	case isLetter(c):
+		m.Words++
+		return InWord{}
+	case isDigit(c):
+		m.Numbers++
+		return InNumber{}
+	}
+	return Idle{}
+}
+
+func (InWord) Next(c byte, m *Machine) State {
+	if isLetter(c) || isDigit(c) {
+		return InWord{}
+	}
+	return Idle{}
+}
+
+func (InNumber) Next(c byte, m *Machine) State {
+	switch {
+	case isDigit(c):
+		return InNumber{}
+	case isLetter(c):
+		m.Words++  // "12ab" splits into a number and a word
+		return InWord{}
+	}
+	return Idle{}
+}
+
+// Switch design (integer state)
+const (
+	stateIdle = iota
+	stateWord
+	stateNumber
+)
+
+func runSwitch(input []byte) Machine {
+	var m Machine
+	state := stateIdle
+	for _, c := range input {
+		switch state {
+		case stateIdle:
+			switch {
+			case isLetter(c):
+				m.Words++
+				state = stateWord
+			case isDigit(c):
+				m.Numbers++
+				state = stateNumber
+			}
+		case stateWord:
+			if !isLetter(c) && !isDigit(c) {
+				state = stateIdle
+			}
+		case stateNumber:
+			switch {
+			case isDigit(c):
+			case isLetter(c):
+				m.Words++
+				state = stateWord
+			default:
+				state = stateIdle
+			}
+		}
+	}
+	return m
+}
+
+func runInterface(input []byte) Machine {
+	var m Machine
+	var state State = Idle{}
+	for _, c := range input {
+		state = state.Next(c, &m)  // Interface call per transition
+	}
+	return m
+}
+
+// Random text: short words and numbers separated by spaces and punctuation
+func makeInput(n int) []byte {
+	r := rand.New(rand.NewSource(1))
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789  ,."
+	input := make([]byte, n)
+	for i := range input {
+		input[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return input
+}
+
+func benchmark(input []byte, iterations int, run func([]byte) Machine) (time.Duration, Machine) {
+	var m Machine
+	start := time.Now()
+	for iter := 0; iter < iterations; iter++ {
+		m = run(input)
+	}
+	return time.Since(start), m
+}
+
+func main() {
+	const n = 10000000  // 10 million input bytes
+	const iterations = 5
+
+	input := makeInput(n)
+
+	fmt.Println("Benchmarking Go state machine: State interface vs integer switch")
+	fmt.Printf("Input bytes: %d\n", n)
+	fmt.Printf("Iterations: %d\n", iterations)
+	fmt.Printf("Total transitions: %d\n\n", n*iterations)
+
+	// Warm up
+	benchmark(input[:1000], 10, runInterface)
+	benchmark(input[:1000], 10, runSwitch)
+
+	// Benchmark interface states
+	interfaceTime, im := benchmark(input, iterations, runInterface)
+	interfacePer := float64(interfaceTime.Nanoseconds()) / float64(n*iterations)
+
+	fmt.Println("State interface (one type per state):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(interfaceTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per transition: %.2f ns\n\n", interfacePer)
+
+	// Benchmark switch
+	switchTime, sm := benchmark(input, iterations, runSwitch)
+	switchPer := float64(switchTime.Nanoseconds()) / float64(n*iterations)
+
+	fmt.Println("Integer state + switch:")
+	fmt.Printf("  Total time: %.2f ms\n", float64(switchTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per transition: %.2f ns\n\n", switchPer)
+
+	// Both machines must agree
+	if im != sm {
+		panic("machines disagree")
+	}
+
+	// Calculate speedup
+	speedup := float64(interfaceTime) / float64(switchTime)
+	fmt.Printf("Tokens: %d words, %d numbers\n", sm.Words, sm.Numbers)
+	fmt.Printf("Overhead: %.2f ns per transition\n", interfacePer-switchPer)
+	fmt.Printf("Speedup: %.2fx faster for switch\n", speedup)
+	fmt.Println("\nConclusion: The State pattern pays an indirect call per transition")
+	fmt.Println("and hides the machine from the optimizer. An integer and a switch")
+	fmt.Println("keep the whole machine in one function: plain data, direct code.")
+	fmt.Println("Random input makes transitions hard to predict for both machines,")
+	fmt.Println("so branch mispredictions make up much of both timings.")
+}
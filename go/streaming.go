@@ -0,0 +1,118 @@
+// Benchmark 7: Streaming vs materialized aggregation over value slices
+// Run: go run streaming.go
+//
+// A common pipeline shape: transform every point, then aggregate.
+// Written as two steps, it materializes an intermediate []Point:
+//   - one allocation the size of the input per pass
+//   - a second full pass over memory to read it back
+// Streaming fuses transform and aggregate into one loop: no intermediate slice.
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Global to prevent optimizer from eliminating work
+var gSum int64
+
+func transform(p Point) Point {
+	return Point{X: p.X*2 + 1, Y: p.Y - 3}
+}
+
+// Materialize the transformed points, then aggregate them
+func benchmarkMaterialized(points []Point, iterations int) (time.Duration, uint64, uint64) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		moved := make([]Point, len(points))  // Intermediate slice (heap)
+		for i, p := range points {
+			moved[i] = transform(p)
+		}
+
+		sum := int64(0)
+		for _, p := range moved {
+			sum += int64(p.X + p.Y)
+		}
+		gSum = sum
+	}
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc
+}
+
+// Transform and aggregate in one pass (no intermediate slice)
+func benchmarkStreaming(points []Point, iterations int) (time.Duration, uint64, uint64) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+
+	for iter := 0; iter < iterations; iter++ {
+		sum := int64(0)
+		for _, p := range points {
+			q := transform(p)
+			sum += int64(q.X + q.Y)
+		}
+		gSum = sum
+	}
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc
+}
+
+func main() {
+	const n = 1000000  // 1 million points
+	const iterations = 100
+
+	points := make([]Point, n)
+	for i := 0; i < n; i++ {
+		points[i] = Point{X: i, Y: i}
+	}
+
+	fmt.Println("Benchmarking Go aggregation: materialized vs streaming")
+	fmt.Printf("Elements: %d\n", n)
+	fmt.Printf("Iterations: %d\n\n", iterations)
+
+	// Warm up
+	benchmarkMaterialized(points[:1000], 10)
+	benchmarkStreaming(points[:1000], 10)
+
+	// Benchmark materialized pipeline
+	matTime, matAllocs, matBytes := benchmarkMaterialized(points, iterations)
+	matPerElement := float64(matTime.Nanoseconds()) / float64(n*iterations)
+
+	fmt.Println("Materialized (transform into []Point, then aggregate):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(matTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per element: %.2f ns\n", matPerElement)
+	fmt.Printf("  Allocs per op: %.1f\n", float64(matAllocs)/float64(iterations))
+	fmt.Printf("  Bytes per op: %d\n\n", matBytes/iterations)
+
+	// Benchmark streaming pipeline
+	strTime, strAllocs, strBytes := benchmarkStreaming(points, iterations)
+	strPerElement := float64(strTime.Nanoseconds()) / float64(n*iterations)
+
+	fmt.Println("Streaming (transform and aggregate in one pass):")
+	fmt.Printf("  Total time: %.2f ms\n", float64(strTime.Microseconds())/1000.0)
+	fmt.Printf("  Time per element: %.2f ns\n", strPerElement)
+	fmt.Printf("  Allocs per op: %.1f\n", float64(strAllocs)/float64(iterations))
+	fmt.Printf("  Bytes per op: %d\n\n", strBytes/iterations)
+
+	// Calculate speedup
+	speedup := float64(matTime) / float64(strTime)
+	fmt.Printf("Speedup: %.2fx faster for streaming\n", speedup)
+	fmt.Printf("Saved: %.1f MB allocated per op\n", float64(matBytes-strBytes)/float64(iterations)/(1<<20))
+	fmt.Println("\nConclusion: The intermediate slice costs an allocation, a zeroing pass,")
+	fmt.Println("a write pass and a read pass. Fusing the loop removes all four.")
+}